@@ -0,0 +1,77 @@
+package cva
+
+// resultCache caches the generated class lists of a component.
+type resultCache[P any] interface {
+	// get returns the cached class list of the slot for the props, if any.
+	get(props P, slot string) (string, bool)
+	// put caches the class list of the slot for the props.
+	put(props P, slot string, classes string)
+	stats() CacheStats
+	clear()
+}
+
+type slotKey[K comparable] struct {
+	key  K
+	slot string
+}
+
+type keyedCache[P any, K comparable] struct {
+	key   func(P) K
+	cache *lru[slotKey[K], string]
+}
+
+func (c *keyedCache[P, K]) get(props P, slot string) (string, bool) {
+	return c.cache.get(slotKey[K]{c.key(props), slot})
+}
+
+func (c *keyedCache[P, K]) put(props P, slot string, classes string) {
+	c.cache.put(slotKey[K]{c.key(props), slot}, classes)
+}
+
+func (c *keyedCache[P, K]) stats() CacheStats {
+	return c.cache.stats()
+}
+
+func (c *keyedCache[P, K]) clear() {
+	c.cache.clear()
+}
+
+// Cache makes the component cache its generated class lists, keyed by props, for up to size
+// distinct props and slot combinations. Once full, the least recently used class list is evicted.
+// A size of zero or less makes the cache unbounded.
+//
+// Cached class lists are returned by Classes and Slots without evaluating any of the component's
+// options, so every option must only depend on the props. In particular, changing the default
+// merger with SetDefaultMerger does not affect already cached class lists; use ClearCache if
+// needed.
+//
+// The cache is safe for concurrent use.
+func Cache[P comparable](size int) Option[P] {
+	return CacheFunc(size, func(p P) P { return p })
+}
+
+// CacheFunc is like Cache, but caches the class lists by the keys returned by the key function,
+// allowing components whose props are not comparable to be cached. Props with the same key must
+// produce the same class lists.
+func CacheFunc[P any, K comparable](size int, key func(P) K) Option[P] {
+	return func(c *Cva[P]) {
+		c.cache = &keyedCache[P, K]{key: key, cache: newLRU[slotKey[K], string](size)}
+	}
+}
+
+// CacheStats returns the statistics of the component's cache, as configured with Cache or
+// CacheFunc. It returns zero statistics if the component isn't cached.
+func (c *Cva[P]) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.stats()
+}
+
+// ClearCache empties the component's cache, as configured with Cache or CacheFunc, and resets its
+// statistics.
+func (c *Cva[P]) ClearCache() {
+	if c.cache != nil {
+		c.cache.clear()
+	}
+}
@@ -0,0 +1,153 @@
+package cva
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	type Props struct {
+		Size string
+	}
+
+	newButton := func(calls *int, opts ...Option[Props]) *Cva[Props] {
+		return New(append([]Option[Props]{
+			Base[Props]("button"),
+			Classes(func(p Props) string {
+				*calls++
+				return "size-" + p.Size
+			}),
+			InSlot("icon", Classes(func(p Props) string {
+				*calls++
+				return "icon-" + p.Size
+			})),
+		}, opts...)...)
+	}
+
+	t.Run("classes", func(t *testing.T) {
+		calls := 0
+		button := newButton(&calls, Cache[Props](4))
+
+		for range 3 {
+			if got, want := button.Classes(Props{"small"}), "button size-small"; got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("calls = %d, want %d", calls, 1)
+		}
+
+		want := CacheStats{Hits: 2, Misses: 1, Len: 1, Size: 4}
+		if got := button.CacheStats(); got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("slots", func(t *testing.T) {
+		calls := 0
+		button := newButton(&calls, Cache[Props](4))
+
+		button.Classes(Props{"small"})
+		slots := button.Slots(Props{"small"})
+		if got, want := slots.Get("icon"), "icon-small"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		button.Slots(Props{"small"})
+		if calls != 2 {
+			t.Errorf("calls = %d, want %d", calls, 2)
+		}
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		calls := 0
+		button := newButton(&calls, Cache[Props](1))
+
+		button.Classes(Props{"small"})
+		button.Classes(Props{"large"})
+		button.Classes(Props{"small"})
+		if calls != 3 {
+			t.Errorf("calls = %d, want %d", calls, 3)
+		}
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		calls := 0
+		button := newButton(&calls, Cache[Props](4))
+
+		button.Classes(Props{"small"})
+		button.ClearCache()
+		button.Classes(Props{"small"})
+		if calls != 2 {
+			t.Errorf("calls = %d, want %d", calls, 2)
+		}
+	})
+
+	t.Run("uncached", func(t *testing.T) {
+		calls := 0
+		button := newButton(&calls)
+
+		button.Classes(Props{"small"})
+		button.Classes(Props{"small"})
+		if calls != 2 {
+			t.Errorf("calls = %d, want %d", calls, 2)
+		}
+		if got := button.CacheStats(); got != (CacheStats{}) {
+			t.Errorf("got %+v, want zero stats", got)
+		}
+	})
+
+	t.Run("key_func", func(t *testing.T) {
+		type ListProps struct {
+			Sizes []string
+		}
+
+		calls := 0
+		list := New(
+			CacheFunc(8, func(p ListProps) string { return strings.Join(p.Sizes, ",") }),
+			Classes(func(p ListProps) []string {
+				calls++
+				return p.Sizes
+			}),
+		)
+
+		list.Classes(ListProps{[]string{"a", "b"}})
+		if got, want := list.Classes(ListProps{[]string{"a", "b"}}), "a b"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		if calls != 1 {
+			t.Errorf("calls = %d, want %d", calls, 1)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		button := New(
+			Cache[Props](2),
+			Base[Props]("button"),
+			MapVariant(
+				func(p Props) string { return p.Size },
+				map[string]string{"small": "h-8", "medium": "h-10", "large": "h-12"},
+			),
+		)
+		want := map[string]string{
+			"small":  "button h-8",
+			"medium": "button h-10",
+			"large":  "button h-12",
+		}
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 100 {
+					size := []string{"small", "medium", "large"}[(i+j)%3]
+					if got := button.Classes(Props{size}); got != want[size] {
+						t.Errorf("got %s, want %s", got, want[size])
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/Roundaround/cva-go/internal/cvaast"
+)
+
+// extract loads the packages matching the patterns, relative to dir, and returns all the class
+// tokens passed to cva functions and methods, sorted and deduplicated.
+func extract(dir string, patterns ...string) ([]string, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir: dir,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []string
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err.Error())
+		}
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("loading packages:\n%s", strings.Join(errs, "\n"))
+	}
+
+	seen := make(map[string]struct{})
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				first, ok := cvaast.ClassArgs[cvaast.CalleeName(pkg.TypesInfo, call.Fun)]
+				if !ok {
+					return true
+				}
+				for i := first; i < len(call.Args); i++ {
+					for _, classes := range constantStrings(pkg.TypesInfo, call.Args[i]) {
+						for _, class := range strings.Fields(classes) {
+							seen[class] = struct{}{}
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	classes := make([]string, 0, len(seen))
+	for class := range seen {
+		classes = append(classes, class)
+	}
+	slices.Sort(classes)
+	return classes, nil
+}
+
+// constantStrings returns all the constant strings within expr, looking through composite
+// literals (slices and map values) and ignoring anything that is not a compile-time constant.
+func constantStrings(info *types.Info, expr ast.Expr) []string {
+	if tv, ok := info.Types[expr]; ok && tv.Value != nil {
+		if tv.Value.Kind() == constant.String {
+			return []string{constant.StringVal(tv.Value)}
+		}
+		return nil
+	}
+
+	var strs []string
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		strs = constantStrings(info, e.X)
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			strs = append(strs, constantStrings(info, elt)...)
+		}
+	}
+	return strs
+}
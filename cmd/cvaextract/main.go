@@ -0,0 +1,64 @@
+// Command cvaextract statically extracts the classes used in cva-go component definitions, so
+// that TailwindCSS can generate them without running the application.
+//
+// It parses and type-checks the given Go packages, finds calls to cva.Base, cva.Static,
+// cva.MapVariant, cva.PredicateVariant, cva.NewCompound (and its n-ary and value set
+// counterparts), cva.When, Variant.Map, Matcher.Then and TagVariants.Maps, and collects every
+// class token passed to them as a constant.
+//
+// Usage:
+//
+//	cvaextract [-format text|json|css] [-o file] [packages]
+//
+// The text format writes one class per line, suitable for a TailwindCSS v3 content file. The json
+// format writes a JSON array, suitable for a TailwindCSS v3 safelist. The css format writes a
+// TailwindCSS v4 @source inline(...) directive. Packages default to ./...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+)
+
+func main() {
+	format := flag.String("format", "text", "output format: text, json or css")
+	output := flag.String("o", "", "output file (defaults to stdout)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: cvaextract [flags] [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	if err := run(*format, *output, patterns); err != nil {
+		fmt.Fprintln(os.Stderr, "cvaextract:", err)
+		os.Exit(1)
+	}
+}
+
+func run(format, output string, patterns []string) error {
+	if !slices.Contains(formats, format) {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	classes, err := extract("", patterns...)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		return write(os.Stdout, format, classes)
+	}
+	// The output is rendered before touching the file, so that errors leave it untouched.
+	var b bytes.Buffer
+	if err := write(&b, format, classes); err != nil {
+		return err
+	}
+	return os.WriteFile(output, b.Bytes(), 0o644)
+}
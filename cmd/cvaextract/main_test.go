@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	got, err := extract(".", "./testdata/button")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"[&_svg]:size-4",
+		"bg-blue-500",
+		"cursor-not-allowed",
+		"focus-visible:ring-2",
+		"h-12",
+		"h-14",
+		"h-7",
+		"h-8",
+		"inline-flex",
+		"items-center",
+		"opacity-50",
+		"pointer-events-none",
+		"px-2",
+		"px-6",
+		"rounded-lg",
+		"rounded-md",
+		"select-none",
+		"text-blue-500",
+		"text-lg",
+		"text-white",
+		"underline",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	classes := []string{"[&_svg]:size-4", "px-2"}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "text",
+			want:   "[&_svg]:size-4\npx-2\n",
+		},
+		{
+			format: "json",
+			want:   "[\n  \"[&_svg]:size-4\",\n  \"px-2\"\n]\n",
+		},
+		{
+			format: "css",
+			want:   "@source inline(\"[&_svg]:size-4 px-2\");\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := write(&buf, test.format, classes); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		if err := write(&bytes.Buffer{}, "yaml", classes); err == nil {
+			t.Error("got nil error, want error")
+		}
+	})
+}
+
+func TestRunUnknownFormat(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "classes.txt")
+	if err := os.WriteFile(output, []byte("existing\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run("bogus", output, []string{"./testdata/button"}); err == nil {
+		t.Error("got nil error, want unknown format")
+	}
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "existing\n" {
+		t.Errorf("got %q, want the existing output untouched", got)
+	}
+}
@@ -0,0 +1,57 @@
+package button
+
+import (
+	"github.com/Roundaround/cva-go"
+)
+
+type Props struct {
+	Size     string
+	Style    string
+	Disabled bool
+	Classes  []string
+}
+
+const focus = "focus-visible:ring-2"
+
+var size = cva.NewVariant(func(p Props) string { return p.Size })
+
+var Button = cva.New(
+	cva.Base[Props]("inline-flex  items-center", focus),
+	cva.Static[Props]("select-none"),
+	cva.MapVariant(
+		func(p Props) string { return p.Style },
+		map[string]string{
+			"primary": "bg-blue-500 text-white",
+			"link":    "text-blue-500 " + "underline",
+		},
+	),
+	cva.MapVariant(
+		func(p Props) bool { return p.Disabled },
+		map[bool][]string{true: {"opacity-50", "cursor-not-allowed"}},
+	),
+	cva.CompoundVariant(
+		func(p Props) (string, string) { return p.Size, p.Style },
+		cva.NewCompound("small", "icon", "[&_svg]:size-4"),
+		cva.NewCompoundOf(cva.OneOf("large"), cva.AnyValue[string](), "text-lg"),
+	),
+	size.Map(map[string]string{"small": "h-8 px-2", "large": "h-12 px-6"}),
+	size.Is("large").Then("rounded-lg"),
+	cva.When(size.IsNot("large"), "rounded-md"),
+	cva.PredicateVariant(func(p Props) bool { return p.Disabled }, "pointer-events-none"),
+	cva.Classes(func(p Props) []string { return p.Classes }),
+)
+
+type TaggedProps struct {
+	Size string `cva:"size"`
+}
+
+var tags = cva.MustParseTags[TaggedProps]()
+
+var Tagged = cva.New(
+	tags.Maps(map[string]map[string]string{
+		"size": {"small": "h-7", "large": "h-14"},
+	}),
+)
+
+// Not a class list, so should be ignored.
+var label = map[string]string{"small": "Small button"}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// formats lists the output formats supported by write.
+var formats = []string{"text", "json", "css"}
+
+// write writes the classes to w in the given format.
+func write(w io.Writer, format string, classes []string) error {
+	switch format {
+	case "text":
+		for _, class := range classes {
+			if _, err := fmt.Fprintln(w, class); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if classes == nil {
+			classes = []string{}
+		}
+		return enc.Encode(classes)
+	case "css":
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(strings.Join(classes, " "))
+		_, err := fmt.Fprintf(w, "@source inline(\"%s\");\n", escaped)
+		return err
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
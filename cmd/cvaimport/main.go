@@ -0,0 +1,50 @@
+// Command cvaimport generates Go source code building the component defined by a JSON or YAML
+// export of an upstream cva definition, along with a props struct and typed constants for its
+// variants, using the spec package:
+//
+//	cvaimport -package ui [-component Button] [-props ButtonProps] [-o button.go] button.json
+//
+// The generated code is written to standard output unless -o is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Roundaround/cva-go/spec"
+)
+
+func main() {
+	var config spec.GoConfig
+	flag.StringVar(&config.Package, "package", "", "package name of the generated code")
+	flag.StringVar(&config.Component, "component", "",
+		"name of the generated component variable (default: the spec's name, capitalized)")
+	flag.StringVar(&config.Props, "props", "",
+		"name of the generated props type (default: the component's name followed by Props)")
+	out := flag.String("o", "", "output file (default: standard output)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: cvaimport -package name [flags] spec.json")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	code, err := spec.GenerateGoFile(flag.Arg(0), config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(code)
+	} else {
+		err = os.WriteFile(*out, code, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,17 @@
+// Command cvavet reports common mistakes in cva-go component definitions, using the analyzer
+// defined by the cvalint package.
+//
+// It can be run on its own, or through go vet:
+//
+//	cvavet [packages]
+//	go vet -vettool=$(which cvavet) [packages]
+package main
+
+import (
+	"github.com/Roundaround/cva-go/cvalint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(cvalint.Analyzer)
+}
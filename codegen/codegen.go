@@ -0,0 +1,548 @@
+// Package codegen generates plain Go functions equivalent to a cva component.
+//
+// The generated functions look the component's class lists up in constant tables, indexed by
+// switch statements over the props' fields, so that rendering a component doesn't evaluate any of
+// its options, join classes or allocate. Alongside them, a test is generated asserting that the
+// functions return the same class lists as the component itself.
+//
+// Generation is intended to be run with go generate, from a small program importing the package
+// defining the component:
+//
+//	//go:build ignore
+//
+//	package main
+//
+//	func main() {
+//		err := codegen.WriteFiles(buttons.Button, codegen.Config{
+//			Package:   "buttons",
+//			Func:      "ButtonClasses",
+//			Component: "Button",
+//		}, "button_gen.go")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// The props type must be a struct type defined in the same package as the generated code. The
+// fields enumerated are the bool fields and the fields of basic types holding a value listed in
+// the component's Schema, e.g. the keys of a MapVariant or the values of a compound variant. The
+// component's output is computed for every combination of the enumerated values, with a single
+// placeholder standing for all values not listed in the Schema, which are assumed to produce the
+// same classes.
+package codegen
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"go/format"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Roundaround/cva-go"
+	"github.com/Roundaround/cva-go/internal/variantvalue"
+)
+
+// Config configures the generated code.
+type Config struct {
+	// Package is the name of the package of the generated code, which must be the package
+	// defining the props type.
+	Package string
+	// Func is the name of the generated function returning the class list of the component's
+	// root element, equivalent to Cva.Classes.
+	Func string
+	// SlotFuncs maps the names of the component's slots to the names of the generated functions
+	// returning their class lists. Slots not listed are not generated.
+	SlotFuncs map[string]string
+	// Component is the Go expression referring to the component in the generated test, e.g.
+	// "Button".
+	Component string
+	// Fields lists the props fields to enumerate. By default, the bool fields and the fields
+	// holding a value listed in the component's Schema are enumerated.
+	Fields []string
+	// AllowOpaque allows components with options whose logic cannot be described, such as those
+	// created with PredicateVariant or Variant.Test. They are assumed to only depend on the
+	// enumerated fields, and to treat all values not listed in the Schema alike, which the
+	// generated test can only partially verify.
+	AllowOpaque bool
+}
+
+// otherString is the placeholder standing for all strings not listed in the component's Schema.
+const otherString = "cva-codegen-other"
+
+// field is a single enumerated field of the props.
+type field struct {
+	reflect.StructField
+	// values lists the field's enumerated values. The last one stands for all other values if
+	// hasOther is true; otherwise, every value of the field's type is listed.
+	values   []reflect.Value
+	hasOther bool
+	stride   int
+}
+
+// generator holds the state shared between the generated code and test.
+type generator struct {
+	config    Config
+	propsType reflect.Type
+	fields    []field
+	funcs     []slotFunc
+}
+
+// slotFunc is a single generated function and its table.
+type slotFunc struct {
+	slot  string
+	name  string
+	table []string
+}
+
+// Generate returns the source code of functions equivalent to the component, as configured.
+func Generate[P any](c *cva.Cva[P], config Config) ([]byte, error) {
+	g, err := newGenerator(c, config)
+	if err != nil {
+		return nil, err
+	}
+	return g.code()
+}
+
+// GenerateTest returns the source code of a test asserting that the functions returned by
+// Generate with the same configuration are equivalent to the component.
+func GenerateTest[P any](c *cva.Cva[P], config Config) ([]byte, error) {
+	g, err := newGenerator(c, config)
+	if err != nil {
+		return nil, err
+	}
+	return g.test()
+}
+
+// WriteFiles writes the generated code to the given path, and the generated test to the
+// corresponding _test.go file.
+func WriteFiles[P any](c *cva.Cva[P], config Config, path string) error {
+	g, err := newGenerator(c, config)
+	if err != nil {
+		return err
+	}
+	code, err := g.code()
+	if err != nil {
+		return err
+	}
+	test, err := g.test()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, code, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(path, ".go")+"_test.go", test, 0o644)
+}
+
+func newGenerator[P any](c *cva.Cva[P], config Config) (*generator, error) {
+	if config.Package == "" || config.Func == "" || config.Component == "" {
+		return nil, errors.New("codegen: Package, Func and Component must be set")
+	}
+
+	t := reflect.TypeFor[P]()
+	if t.Kind() != reflect.Struct || t.Name() == "" {
+		return nil, fmt.Errorf("codegen: props type %s is not a named struct type", t)
+	}
+
+	schema := c.Schema()
+	values := make(map[string][]namedValue)
+	if err := collect(schema, config.AllowOpaque, values); err != nil {
+		return nil, err
+	}
+
+	g := &generator{config: config, propsType: t}
+	if err := g.enumerate(values); err != nil {
+		return nil, err
+	}
+
+	g.funcs = []slotFunc{{slot: "", name: config.Func}}
+	for _, slot := range slices.Sorted(maps.Keys(config.SlotFuncs)) {
+		if !slices.Contains(schema.Slots, slot) {
+			return nil, fmt.Errorf("codegen: component has no slot %q", slot)
+		}
+		g.funcs = append(g.funcs, slotFunc{slot: slot, name: config.SlotFuncs[slot]})
+	}
+
+	g.fill(func(props reflect.Value) cva.SlotClasses {
+		return c.Slots(props.Interface().(P))
+	})
+	return g, nil
+}
+
+// namedValue is a value listed in the component's schema, along with the name of its variant.
+type namedValue struct {
+	name  string
+	value reflect.Value
+}
+
+// collect gathers the values listed in the schema, keyed by type, checking that every option can
+// be generated.
+func collect(s cva.Schema, allowOpaque bool, values map[string][]namedValue) error {
+	add := func(name string, v any) {
+		if v == nil {
+			return
+		}
+		rv := reflect.ValueOf(v)
+		key := rv.Type().String()
+		values[key] = append(values[key], namedValue{name, rv})
+	}
+	addVariant := func(v cva.VariantSchema) {
+		for _, val := range v.Values {
+			add(v.Name, val)
+		}
+		if v.HasDefault {
+			add(v.Name, v.Default)
+		}
+	}
+
+	var walkCondition func(cond cva.Condition) error
+	walkCondition = func(cond cva.Condition) error {
+		switch cond.Op {
+		case cva.OpFunc, cva.OpTest:
+			if !allowOpaque {
+				return fmt.Errorf(
+					"codegen: condition %s cannot be generated without Config.AllowOpaque", cond)
+			}
+		}
+		addVariant(cond.Variant)
+		for _, v := range cond.Values {
+			add(cond.Variant.Name, v)
+		}
+		for _, operand := range cond.Operands {
+			if err := walkCondition(operand); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i, opt := range s.Options {
+		if opt.Kind == cva.KindClasses {
+			return fmt.Errorf("codegen: option %d of component %q computes its classes dynamically",
+				i, s.Name)
+		}
+		for _, v := range opt.Variants {
+			addVariant(v)
+		}
+		for _, c := range opt.Cases {
+			for pos, vals := range c.Values {
+				name := ""
+				if pos < len(opt.Variants) {
+					name = opt.Variants[pos].Name
+				}
+				for _, v := range vals {
+					add(name, v)
+				}
+			}
+		}
+		if opt.Condition != nil {
+			if err := walkCondition(*opt.Condition); err != nil {
+				return err
+			}
+		}
+		if opt.Inherited != nil {
+			if err := collect(*opt.Inherited, allowOpaque, values); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// enumerate selects the fields to enumerate and their values.
+func (g *generator) enumerate(values map[string][]namedValue) error {
+	t := g.propsType
+
+	var candidates []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() || !variantvalue.Supported(f.Type.Kind()) {
+			continue
+		}
+		if g.config.Fields != nil && !slices.Contains(g.config.Fields, f.Name) {
+			continue
+		}
+		if f.Type.PkgPath() != "" && f.Type.PkgPath() != t.PkgPath() {
+			return fmt.Errorf("codegen: field %s has type %s from another package", f.Name, f.Type)
+		}
+		candidates = append(candidates, f)
+	}
+	for _, name := range g.config.Fields {
+		if !slices.ContainsFunc(candidates, func(f reflect.StructField) bool {
+			return f.Name == name
+		}) {
+			return fmt.Errorf("codegen: props type %s has no supported field %s", t, name)
+		}
+	}
+
+	size := 1
+	for _, f := range candidates {
+		var vals []reflect.Value
+		if f.Type.Kind() == reflect.Bool {
+			vals = []reflect.Value{reflect.ValueOf(false), reflect.ValueOf(true)}
+		} else {
+			for _, nv := range values[f.Type.String()] {
+				if nv.name != "" && !strings.EqualFold(nv.name, f.Name) &&
+					slices.ContainsFunc(candidates, func(other reflect.StructField) bool {
+						return other.Type == f.Type && strings.EqualFold(nv.name, other.Name)
+					}) {
+					// The value belongs to another field named after its variant.
+					continue
+				}
+				vals = append(vals, nv.value)
+			}
+			if len(vals) == 0 && g.config.Fields == nil {
+				continue
+			}
+			vals = append(vals, reflect.Zero(f.Type))
+			slices.SortFunc(vals, compareValues)
+			vals = slices.CompactFunc(vals, func(a, b reflect.Value) bool { return a.Equal(b) })
+		}
+
+		fd := field{StructField: f, values: vals, stride: size}
+		if other, ok := otherValue(f.Type, vals); ok {
+			fd.values = append(fd.values, other)
+			fd.hasOther = true
+		}
+		g.fields = append(g.fields, fd)
+
+		size *= len(fd.values)
+		if size > cva.MaxCompiledEntries {
+			return fmt.Errorf("codegen: component requires more than %d table entries",
+				cva.MaxCompiledEntries)
+		}
+	}
+	return nil
+}
+
+// fill computes the tables of every generated function.
+func (g *generator) fill(slots func(props reflect.Value) cva.SlotClasses) {
+	size := 1
+	for _, f := range g.fields {
+		size *= len(f.values)
+	}
+
+	for key := range size {
+		props := reflect.New(g.propsType).Elem()
+		for _, f := range g.fields {
+			props.FieldByIndex(f.Index).Set(f.values[key/f.stride%len(f.values)])
+		}
+		classes := slots(props)
+		for i := range g.funcs {
+			g.funcs[i].table = append(g.funcs[i].table, classes.Get(g.funcs[i].slot))
+		}
+	}
+}
+
+func (g *generator) code() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cva-go codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.config.Package)
+
+	props := g.propsType.Name()
+	keyFunc := unexported(g.config.Func) + "Key"
+	for _, fn := range g.funcs {
+		method := "Classes(p)"
+		if fn.slot != "" {
+			method = fmt.Sprintf("Slots(p).Get(%q)", fn.slot)
+		}
+		table := unexported(fn.name) + "Table"
+		b.WriteString(comment(fmt.Sprintf(
+			"%s returns the same classes as %s.%s, without evaluating its options.",
+			fn.name, g.config.Component, method,
+		)))
+		fmt.Fprintf(&b, "func %s(p %s) string {\n\treturn %s[%s(p)]\n}\n\n",
+			fn.name, props, table, keyFunc)
+	}
+
+	b.WriteString(comment(fmt.Sprintf(
+		"%s returns the index of the props' class lists in the lookup tables.", keyFunc,
+	)))
+	fmt.Fprintf(&b, "func %s(p %s) int {\n\tkey := 0\n", keyFunc, props)
+	for _, f := range g.fields {
+		if f.Type.Kind() == reflect.Bool {
+			fmt.Fprintf(&b, "\tif p.%s {\n\t\tkey += %d\n\t}\n", f.Name, f.stride)
+			continue
+		}
+		fmt.Fprintf(&b, "\tswitch p.%s {\n", f.Name)
+		for i, v := range f.values {
+			if f.hasOther && i == len(f.values)-1 {
+				b.WriteString("\tdefault:\n")
+			} else if i > 0 || f.hasOther {
+				fmt.Fprintf(&b, "\tcase %s:\n", literal(v))
+			} else {
+				continue
+			}
+			if i > 0 {
+				fmt.Fprintf(&b, "\t\tkey += %d\n", i*f.stride)
+			}
+		}
+		b.WriteString("\t}\n")
+	}
+	b.WriteString("\treturn key\n}\n")
+
+	for _, fn := range g.funcs {
+		fmt.Fprintf(&b, "\nvar %sTable = [...]string{\n", unexported(fn.name))
+		for _, classes := range fn.table {
+			fmt.Fprintf(&b, "\t%s,\n", strconv.Quote(classes))
+		}
+		b.WriteString("}\n")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+func (g *generator) test() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cva-go codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\nimport \"testing\"\n\n", g.config.Package)
+	fmt.Fprintf(&b, "func Test%sGenerated(t *testing.T) {\n", g.config.Func)
+
+	var assigns []string
+	for i, f := range g.fields {
+		name := "v" + f.Name
+		vals := make([]string, len(f.values))
+		for j, v := range f.values {
+			vals[j] = literal(v)
+		}
+		fmt.Fprintf(&b, "%sfor _, %s := range []%s{%s} {\n",
+			strings.Repeat("\t", i+1), name, f.Type.Name(), strings.Join(vals, ", "))
+		assigns = append(assigns, f.Name+": "+name)
+	}
+
+	indent := strings.Repeat("\t", len(g.fields)+1)
+	fmt.Fprintf(&b, "%sp := %s{%s}\n", indent, g.propsType.Name(), strings.Join(assigns, ", "))
+	for _, fn := range g.funcs {
+		want := g.config.Component + ".Classes(p)"
+		if fn.slot != "" {
+			want = fmt.Sprintf("%s.Slots(p).Get(%q)", g.config.Component, fn.slot)
+		}
+		fmt.Fprintf(&b, "%sif got, want := %s(p), %s; got != want {\n", indent, fn.name, want)
+		fmt.Fprintf(&b, "%s\tt.Errorf(\"%s(%%+v): got %%s, want %%s\", p, got, want)\n",
+			indent, fn.name)
+		fmt.Fprintf(&b, "%s}\n", indent)
+	}
+	for i := len(g.fields); i > 0; i-- {
+		fmt.Fprintf(&b, "%s}\n", strings.Repeat("\t", i))
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// otherValue returns a value of the type not present in vals, standing for all values not listed
+// in the component's Schema. The vals must be sorted and unique. It reports false for bools, and
+// for types whose values are all listed, which leave no other value to stand for.
+func otherValue(t reflect.Type, vals []reflect.Value) (reflect.Value, bool) {
+	other := reflect.New(t).Elem()
+	free := func() bool { return !slices.ContainsFunc(vals, other.Equal) }
+	first, last := vals[0], vals[len(vals)-1]
+
+	// Try past either end of the listed values, then between them.
+	switch t.Kind() {
+	case reflect.String:
+		other.SetString(otherString)
+		for i := 2; !free(); i++ {
+			other.SetString(otherString + "-" + strconv.Itoa(i))
+		}
+		return other, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		candidates := []int64{last.Int() + 1, first.Int() - 1}
+		for i := 1; i < len(vals); i++ {
+			candidates = append(candidates, vals[i-1].Int()+1)
+		}
+		for _, c := range candidates {
+			if !other.OverflowInt(c) {
+				if other.SetInt(c); free() {
+					return other, true
+				}
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		candidates := []uint64{last.Uint() + 1, first.Uint() - 1}
+		for i := 1; i < len(vals); i++ {
+			candidates = append(candidates, vals[i-1].Uint()+1)
+		}
+		for _, c := range candidates {
+			if !other.OverflowUint(c) {
+				if other.SetUint(c); free() {
+					return other, true
+				}
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		candidates := []float64{last.Float() + 1, first.Float() - 1}
+		for i := 1; i < len(vals); i++ {
+			candidates = append(candidates, (vals[i-1].Float()+vals[i].Float())/2)
+		}
+		for _, c := range candidates {
+			if other.SetFloat(c); free() {
+				return other, true
+			}
+		}
+	}
+	return other, false
+}
+
+// compareValues orders values of the same type.
+func compareValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Bool:
+		return cmp.Compare(strconv.FormatBool(a.Bool()), strconv.FormatBool(b.Bool()))
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	}
+	return 0
+}
+
+// literal formats a value as a Go literal.
+func literal(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return strconv.Quote(v.String())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// comment formats the text as a line comment, wrapped at 100 columns.
+func comment(text string) string {
+	var b strings.Builder
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 100 && line != "//" {
+			b.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	b.WriteString(line + "\n")
+	return b.String()
+}
+
+// unexported returns the identifier with its first letter lowercased.
+func unexported(name string) string {
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
@@ -0,0 +1,249 @@
+package codegen
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Roundaround/cva-go"
+	"github.com/Roundaround/cva-go/codegen/internal/testbutton"
+)
+
+var update = flag.Bool("update", false, "rewrite the generated files of the test package")
+
+func TestGenerate(t *testing.T) {
+	config := Config{
+		Package:     "testbutton",
+		Func:        "ButtonClasses",
+		SlotFuncs:   map[string]string{"icon": "ButtonIconClasses"},
+		Component:   "Button",
+		AllowOpaque: true,
+	}
+	path := "internal/testbutton/button_gen.go"
+
+	if *update {
+		if err := WriteFiles(testbutton.Button, config, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		generate func(*cva.Cva[testbutton.Props], Config) ([]byte, error)
+		path     string
+	}{
+		{name: "code", generate: Generate[testbutton.Props], path: path},
+		{
+			name:     "test",
+			generate: GenerateTest[testbutton.Props],
+			path:     strings.TrimSuffix(path, ".go") + "_test.go",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.generate(testbutton.Button, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := os.ReadFile(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("generated code differs from %s, run go test with -update", test.path)
+			}
+		})
+	}
+}
+
+func TestGeneratedAllocations(t *testing.T) {
+	p := testbutton.Props{Size: testbutton.Large, Style: "primary", Disabled: true}
+	allocs := testing.AllocsPerRun(100, func() {
+		testbutton.ButtonClasses(p)
+		testbutton.ButtonIconClasses(p)
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations, want 0", allocs)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	type Props struct {
+		Size     string
+		Disabled bool
+		Tags     []string
+	}
+
+	config := Config{Package: "p", Func: "F", Component: "C"}
+
+	tests := []struct {
+		name   string
+		c      *cva.Cva[Props]
+		config Config
+		want   string
+	}{
+		{
+			name:   "missing_config",
+			c:      cva.New[Props](),
+			config: Config{Func: "F"},
+			want:   "must be set",
+		},
+		{
+			name: "dynamic",
+			c: cva.New(
+				cva.Classes(func(p Props) []string { return p.Tags }),
+			),
+			config: config,
+			want:   "dynamically",
+		},
+		{
+			name: "opaque",
+			c: cva.New(
+				cva.PredicateVariant(func(p Props) bool { return p.Disabled }, "opacity-50"),
+			),
+			config: config,
+			want:   "AllowOpaque",
+		},
+		{
+			name:   "unknown_slot",
+			c:      cva.New[Props](),
+			config: Config{Package: "p", Func: "F", Component: "C", SlotFuncs: map[string]string{"icon": "I"}},
+			want:   `no slot "icon"`,
+		},
+		{
+			name:   "unknown_field",
+			c:      cva.New[Props](),
+			config: Config{Package: "p", Func: "F", Component: "C", Fields: []string{"Tags"}},
+			want:   "no supported field Tags",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Generate(test.c, test.config)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got error %v, want %s", err, test.want)
+			}
+		})
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	type Props struct {
+		Size  string
+		Style string
+		Other string
+		Count uint8
+	}
+
+	size := cva.NewVariant(func(p Props) string { return p.Size }).WithName("size")
+	c := cva.New(
+		size.Map(map[string]string{"small": "h-8", "large": "h-12"}),
+		cva.Label("style", cva.MapVariant(
+			func(p Props) string { return p.Style },
+			map[string]string{"primary": "bg-blue-500"},
+		)),
+		cva.MapVariant(func(p Props) uint8 { return p.Count }, map[uint8]string{255: "max"}),
+	)
+
+	g, err := newGenerator(c, Config{Package: "p", Func: "F", Component: "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string][]string)
+	for _, f := range g.fields {
+		for _, v := range f.values {
+			got[f.Name] = append(got[f.Name], literal(v))
+		}
+	}
+	want := map[string][]string{
+		"Size":  {`""`, `"large"`, `"small"`, `"cva-codegen-other"`},
+		"Style": {`""`, `"primary"`, `"cva-codegen-other"`},
+		"Count": {"0", "255", "1"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got fields %v, want %v", got, want)
+	}
+	for name, vals := range want {
+		if strings.Join(got[name], " ") != strings.Join(vals, " ") {
+			t.Errorf("%s: got %v, want %v", name, got[name], vals)
+		}
+	}
+}
+
+func TestUnlistedValues(t *testing.T) {
+	type Props struct {
+		Count uint8
+		Delta int8
+		Name  string
+	}
+
+	tests := []struct {
+		name     string
+		c        *cva.Cva[Props]
+		unlisted Props
+		want     string
+	}{
+		{
+			name: "uint8",
+			c: cva.New(cva.MapVariant(
+				func(p Props) uint8 { return p.Count },
+				map[uint8]string{0: "a", 255: "b"},
+			)),
+			unlisted: Props{Count: 5},
+			want:     "1",
+		},
+		{
+			name: "int8",
+			c: cva.New(cva.MapVariant(
+				func(p Props) int8 { return p.Delta },
+				map[int8]string{-128: "a", 0: "b", 127: "c"},
+			)),
+			unlisted: Props{Delta: 5},
+			want:     "-127",
+		},
+		{
+			name: "string",
+			c: cva.New(cva.MapVariant(
+				func(p Props) string { return p.Name },
+				map[string]string{"": "a", otherString: "b"},
+			)),
+			unlisted: Props{Name: "other"},
+			want:     `"cva-codegen-other-2"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := newGenerator(test.c, Config{Package: "p", Func: "F", Component: "C"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(g.fields) != 1 || !g.fields[0].hasOther {
+				t.Fatalf("got fields %+v, want a single field with a placeholder", g.fields)
+			}
+			f := g.fields[0]
+			if got := literal(f.values[len(f.values)-1]); got != test.want {
+				t.Errorf("placeholder: got %s, want %s", got, test.want)
+			}
+			// The placeholder's entry is the last of the table, selected by the default branch.
+			table := g.funcs[0].table
+			if got, want := table[len(table)-1], test.c.Classes(test.unlisted); got != want {
+				t.Errorf("unlisted value: got %q, want %q", got, want)
+			}
+
+			code, err := g.code()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"case " + literal(f.values[0]) + ":", "default:"} {
+				if !strings.Contains(string(code), want) {
+					t.Errorf("got code\n%s\nwant %s", code, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Package testbutton defines a component used to test the code generator. The generated files
+// are kept up to date by the codegen package's tests, run with -update to rewrite them.
+package testbutton
+
+import (
+	"github.com/Roundaround/cva-go"
+	"github.com/Roundaround/cva-go/tailwind"
+)
+
+type Size string
+
+const (
+	Small  Size = "small"
+	Medium Size = "medium"
+	Large  Size = "large"
+)
+
+type Props struct {
+	Size     Size
+	Style    string
+	Level    int
+	Disabled bool
+	Label    string
+}
+
+var size = cva.NewVariant(func(p Props) Size { return p.Size }).
+	WithName("size").
+	WithValues(Small, Medium, Large).
+	WithDefault(Medium)
+
+var base = cva.New(
+	cva.Base[Props]("inline-flex px-4 py-2"),
+	size.Map(map[Size]string{Small: "h-8 px-2", Large: "h-12 px-6"}),
+)
+
+var Button = cva.New(
+	cva.WithMerger[Props](tailwind.New(tailwind.Config{})),
+	cva.Inherit(base, func(p Props) Props { return p }),
+	cva.Label("style", cva.MapVariant(
+		func(p Props) string { return p.Style },
+		map[string]string{"primary": "bg-blue-500", "link": "px-0 underline"},
+	)),
+	cva.MapVariant(
+		func(p Props) int { return p.Level },
+		map[int]string{1: "shadow-sm", 2: "shadow-md"},
+	),
+	cva.CompoundVariant(
+		func(p Props) (string, bool) { return p.Style, p.Disabled },
+		cva.NewCompound("primary", true, "bg-blue-300"),
+	),
+	size.Is(Large).Then("text-lg"),
+	cva.PredicateVariant(func(p Props) bool { return p.Disabled }, "opacity-50"),
+	cva.InSlot("icon",
+		cva.Base[Props]("size-4"),
+		size.Is(Small).Then("size-3"),
+	),
+)
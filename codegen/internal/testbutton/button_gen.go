@@ -0,0 +1,1025 @@
+// Code generated by cva-go codegen. DO NOT EDIT.
+
+package testbutton
+
+// ButtonClasses returns the same classes as Button.Classes(p), without evaluating its options.
+func ButtonClasses(p Props) string {
+	return buttonClassesTable[buttonClassesKey(p)]
+}
+
+// ButtonIconClasses returns the same classes as Button.Slots(p).Get("icon"), without evaluating its
+// options.
+func ButtonIconClasses(p Props) string {
+	return buttonIconClassesTable[buttonClassesKey(p)]
+}
+
+// buttonClassesKey returns the index of the props' class lists in the lookup tables.
+func buttonClassesKey(p Props) int {
+	key := 0
+	switch p.Size {
+	case "":
+	case "large":
+		key += 1
+	case "medium":
+		key += 2
+	case "small":
+		key += 3
+	default:
+		key += 4
+	}
+	switch p.Style {
+	case "":
+	case "link":
+		key += 5
+	case "primary":
+		key += 10
+	default:
+		key += 15
+	}
+	switch p.Level {
+	case 0:
+	case 1:
+		key += 20
+	case 2:
+		key += 40
+	default:
+		key += 60
+	}
+	if p.Disabled {
+		key += 80
+	}
+	switch p.Label {
+	case "":
+	case "primary":
+		key += 160
+	default:
+		key += 320
+	}
+	return key
+}
+
+var buttonClassesTable = [...]string{
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-12 px-0 underline text-lg",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-8 px-0 underline",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 text-lg",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-8 px-2 bg-blue-500",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-8 px-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex py-2 h-12 px-0 underline shadow-sm text-lg",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex py-2 h-8 px-0 underline shadow-sm",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 shadow-sm text-lg",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex py-2 h-8 px-2 bg-blue-500 shadow-sm",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-8 px-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-8 px-2 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex py-2 h-12 px-0 underline shadow-md text-lg",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex py-2 h-8 px-0 underline shadow-md",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 shadow-md text-lg",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex py-2 h-8 px-2 bg-blue-500 shadow-md",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-8 px-2 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-12 px-0 underline text-lg",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-8 px-0 underline",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 text-lg",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-8 px-2 bg-blue-500",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-12 px-0 underline text-lg opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-8 px-0 underline opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-0 underline shadow-sm text-lg opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-0 underline shadow-md text-lg opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-12 px-0 underline text-lg opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-8 px-0 underline opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-12 px-0 underline text-lg",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-8 px-0 underline",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 text-lg",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-8 px-2 bg-blue-500",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-8 px-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex py-2 h-12 px-0 underline shadow-sm text-lg",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex py-2 h-8 px-0 underline shadow-sm",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 shadow-sm text-lg",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex py-2 h-8 px-2 bg-blue-500 shadow-sm",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-8 px-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-8 px-2 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex py-2 h-12 px-0 underline shadow-md text-lg",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex py-2 h-8 px-0 underline shadow-md",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 shadow-md text-lg",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex py-2 h-8 px-2 bg-blue-500 shadow-md",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-8 px-2 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-12 px-0 underline text-lg",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-8 px-0 underline",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 text-lg",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-8 px-2 bg-blue-500",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-12 px-0 underline text-lg opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-8 px-0 underline opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-0 underline shadow-sm text-lg opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-0 underline shadow-md text-lg opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-12 px-0 underline text-lg opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-8 px-0 underline opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-12 px-0 underline text-lg",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-8 px-0 underline",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 text-lg",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-8 px-2 bg-blue-500",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-8 px-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex py-2 h-12 px-0 underline shadow-sm text-lg",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex py-2 h-8 px-0 underline shadow-sm",
+	"inline-flex py-2 px-0 underline shadow-sm",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 shadow-sm text-lg",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex py-2 h-8 px-2 bg-blue-500 shadow-sm",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex py-2 h-8 px-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-sm",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-8 px-2 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex py-2 h-12 px-0 underline shadow-md text-lg",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex py-2 h-8 px-0 underline shadow-md",
+	"inline-flex py-2 px-0 underline shadow-md",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 shadow-md text-lg",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex py-2 h-8 px-2 bg-blue-500 shadow-md",
+	"inline-flex px-4 py-2 bg-blue-500 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex py-2 h-8 px-2 shadow-md",
+	"inline-flex px-4 py-2 shadow-md",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-12 px-0 underline text-lg",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex py-2 h-8 px-0 underline",
+	"inline-flex py-2 px-0 underline",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-12 px-6 bg-blue-500 text-lg",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex py-2 h-8 px-2 bg-blue-500",
+	"inline-flex px-4 py-2 bg-blue-500",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-12 px-6 text-lg",
+	"inline-flex px-4 py-2",
+	"inline-flex py-2 h-8 px-2",
+	"inline-flex px-4 py-2",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-12 px-0 underline text-lg opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-8 px-0 underline opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-0 underline shadow-sm text-lg opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-0 underline shadow-sm opacity-50",
+	"inline-flex py-2 px-0 underline shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-sm text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-sm opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-0 underline shadow-md text-lg opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-0 underline shadow-md opacity-50",
+	"inline-flex py-2 px-0 underline shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-md bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-12 px-6 shadow-md text-lg opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex py-2 h-8 px-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 shadow-md opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-12 px-0 underline text-lg opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex py-2 h-8 px-0 underline opacity-50",
+	"inline-flex py-2 px-0 underline opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-12 px-6 bg-blue-300 text-lg opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex py-2 h-8 px-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 bg-blue-300 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-12 px-6 text-lg opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+	"inline-flex py-2 h-8 px-2 opacity-50",
+	"inline-flex px-4 py-2 opacity-50",
+}
+
+var buttonIconClassesTable = [...]string{
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-4",
+	"size-3",
+	"size-4",
+}
@@ -0,0 +1,25 @@
+// Code generated by cva-go codegen. DO NOT EDIT.
+
+package testbutton
+
+import "testing"
+
+func TestButtonClassesGenerated(t *testing.T) {
+	for _, vSize := range []Size{"", "large", "medium", "small", "cva-codegen-other"} {
+		for _, vStyle := range []string{"", "link", "primary", "cva-codegen-other"} {
+			for _, vLevel := range []int{0, 1, 2, 3} {
+				for _, vDisabled := range []bool{false, true} {
+					for _, vLabel := range []string{"", "primary", "cva-codegen-other"} {
+						p := Props{Size: vSize, Style: vStyle, Level: vLevel, Disabled: vDisabled, Label: vLabel}
+						if got, want := ButtonClasses(p), Button.Classes(p); got != want {
+							t.Errorf("ButtonClasses(%+v): got %s, want %s", p, got, want)
+						}
+						if got, want := ButtonIconClasses(p), Button.Slots(p).Get("icon"); got != want {
+							t.Errorf("ButtonIconClasses(%+v): got %s, want %s", p, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package cva
+
+import "fmt"
+
+// MaxCompiledEntries is the maximum number of entries of the lookup table built by Compile for a
+// single slot of a component.
+const MaxCompiledEntries = 1 << 16
+
+// enumeration describes the finite set of class lists a producer can return.
+type enumeration[P any] struct {
+	// outcomes lists every class list the producer can return.
+	outcomes [][]string
+	// outcome returns the index in outcomes of the class list returned for the props.
+	outcome func(P) int
+}
+
+// compiledSlot is the lookup table of a single slot of a compiled component.
+type compiledSlot[P any] struct {
+	enums   []*enumeration[P]
+	strides []int
+	// dynamic lists the producers which could not be enumerated, in order.
+	dynamic []producer[P]
+	// table holds the final class list for each combination of outcomes, when there are no
+	// dynamic producers.
+	table []string
+	// segments holds, for each combination of outcomes, the joined class lists found before,
+	// between and after the dynamic producers.
+	segments [][]string
+}
+
+// Compile precomputes the component's class lists for every combination of its options' possible
+// outcomes, so that Classes and Slots only evaluate the options' getters and predicates and look
+// the result up in a table, without joining or merging any classes.
+//
+// Options created with Classes cannot be enumerated, and are still evaluated dynamically, along
+// with the component's merger. The classes produced by all other options are still precomputed.
+//
+// Compile should be called once, after the component is created and before it is used, e.g.
+// during program initialization. Like Cache, compiled class lists are computed with the merger in
+// use at the time Compile is called. Compile returns an error, leaving the component unchanged,
+// if a slot would require more than MaxCompiledEntries table entries.
+func (c *Cva[P]) Compile() error {
+	compiled := make(map[string]*compiledSlot[P], len(c.slots)+1)
+	for _, slot := range append([]string{""}, c.slots...) {
+		cs, err := c.compileSlot(slot)
+		if err != nil {
+			return err
+		}
+		compiled[slot] = cs
+	}
+	c.compiled = compiled
+	return nil
+}
+
+func (c *Cva[P]) compileSlot(slot string) (*compiledSlot[P], error) {
+	cs := &compiledSlot[P]{}
+
+	// Producers are split into runs of enumerable producers, separated by dynamic producers.
+	runs := [][]int{nil}
+	size := 1
+	for _, p := range c.producers {
+		if p.slot != slot {
+			continue
+		}
+		var e *enumeration[P]
+		if p.enumerate != nil {
+			e = p.enumerate()
+		}
+		if e == nil {
+			cs.dynamic = append(cs.dynamic, p)
+			runs = append(runs, nil)
+			continue
+		}
+
+		runs[len(runs)-1] = append(runs[len(runs)-1], len(cs.enums))
+		cs.enums = append(cs.enums, e)
+		cs.strides = append(cs.strides, size)
+		size *= len(e.outcomes)
+		if size > MaxCompiledEntries {
+			return nil, fmt.Errorf(
+				"cva: compiling slot %q of component %q requires more than %d entries",
+				slot, c.name, MaxCompiledEntries,
+			)
+		}
+	}
+
+	merger := c.activeMerger()
+	for key := range size {
+		segments := make([]string, len(runs))
+		for r, run := range runs {
+			var parts []string
+			for _, i := range run {
+				outcome := key / cs.strides[i] % len(cs.enums[i].outcomes)
+				parts = append(parts, cs.enums[i].outcomes[outcome]...)
+			}
+			segments[r] = JoinClasses(parts...)
+		}
+
+		if len(cs.dynamic) > 0 {
+			cs.segments = append(cs.segments, segments)
+			continue
+		}
+		classes := segments[0]
+		if merger != nil {
+			classes = JoinClasses(merger.Merge(classes))
+		}
+		cs.table = append(cs.table, classes)
+	}
+	return cs, nil
+}
+
+// key returns the index of the table entry or segments for the props.
+func (cs *compiledSlot[P]) key(props P) int {
+	key := 0
+	for i, e := range cs.enums {
+		key += e.outcome(props) * cs.strides[i]
+	}
+	return key
+}
+
+// classes looks up the class list for the props, which must already be normalized.
+func (cs *compiledSlot[P]) classes(c *Cva[P], props P) string {
+	if cs.table != nil {
+		return cs.table[cs.key(props)]
+	}
+
+	buf := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(buf)
+	*buf = cs.appendTo((*buf)[:0], props)
+	if merger := c.activeMerger(); merger != nil {
+		return JoinClasses(merger.Merge(string(*buf)))
+	}
+	return string(*buf)
+}
+
+// appendTo appends the class list for the props, which must already be normalized, to dst. The
+// component's merger is only applied if the slot has no dynamic producers.
+func (cs *compiledSlot[P]) appendTo(dst []byte, props P) []byte {
+	key := cs.key(props)
+	if cs.table != nil {
+		return append(dst, cs.table[key]...)
+	}
+
+	start := len(dst)
+	segments := cs.segments[key]
+	dst = appendNormalized(dst, segments[0])
+	for i, p := range cs.dynamic {
+		dst = p.appendTo(dst, props)
+		dst = appendNormalized(dst, segments[i+1])
+	}
+	return trimSeparator(dst, start)
+}
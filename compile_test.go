@@ -0,0 +1,126 @@
+package cva
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	type Props struct {
+		Size     string
+		Style    string
+		Disabled bool
+		Custom   string
+	}
+
+	size := NewVariant(func(p Props) string { return p.Size }).
+		WithValues("small", "medium", "large").
+		WithDefault("medium")
+	style := NewVariant(func(p Props) string { return p.Style })
+
+	base := New(
+		Base[Props]("base"),
+		MapVariant(func(p Props) bool { return p.Disabled }, map[bool]string{true: "opacity-50"}),
+	)
+
+	options := func() []Option[Props] {
+		return []Option[Props]{
+			DefaultVariant(func(p *Props) *string { return &p.Style }, "primary"),
+			Inherit(base, func(p Props) Props { return p }),
+			Base[Props]("button px-4"),
+			size.Map(map[string]string{"small": "h-8 px-2", "large": "h-12 px-6"}),
+			MapVariant(
+				func(p Props) string { return p.Style },
+				map[string][]string{"primary": {"bg-blue-500"}, "link": {"underline", "px-0"}},
+			),
+			CompoundVariant(
+				func(p Props) (string, string) { return size.get(p), p.Style },
+				NewCompound("small", "link", "text-sm"),
+				NewCompoundOf(AnyValue[string](), OneOf("link"), "hover:underline"),
+				NewCompoundOf(OneOf("small", "large"), AnyValue[string](), "font-bold"),
+			),
+			size.Is("large").And(style.Is("primary")).Then("shadow"),
+			PredicateVariant(func(p Props) bool { return p.Disabled }, "cursor-not-allowed"),
+			InSlot("icon",
+				Base[Props]("size-4"),
+				size.Is("small").Then("size-3"),
+			),
+		}
+	}
+
+	var props []Props
+	for _, size := range []string{"", "small", "medium", "large", "huge"} {
+		for _, style := range []string{"", "primary", "link", "ghost"} {
+			for _, disabled := range []bool{false, true} {
+				props = append(props, Props{Size: size, Style: style, Disabled: disabled})
+			}
+		}
+	}
+
+	compare := func(t *testing.T, dynamic, compiled *Cva[Props], props []Props) {
+		t.Helper()
+		if err := compiled.Compile(); err != nil {
+			t.Fatalf("got error %v, want nil", err)
+		}
+		for _, p := range props {
+			want, got := dynamic.Slots(p), compiled.Slots(p)
+			for slot, classes := range want {
+				if got[slot] != classes {
+					t.Errorf("%+v slot %q: got %s, want %s", p, slot, got[slot], classes)
+				}
+			}
+			if got, want := compiled.Classes(p), dynamic.Classes(p); got != want {
+				t.Errorf("%+v: got %s, want %s", p, got, want)
+			}
+		}
+	}
+
+	t.Run("enumerable", func(t *testing.T) {
+		compare(t, New(options()...), New(options()...), props)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		opts := append(options(), WithMerger[Props](Dedupe))
+		compare(t, New(opts...), New(opts...), props)
+	})
+
+	t.Run("dynamic", func(t *testing.T) {
+		custom := func() []Option[Props] {
+			return []Option[Props]{
+				Classes(func(p Props) string { return p.Custom }),
+				WithMerger[Props](MergerFunc(func(classes ...string) string {
+					return strings.ReplaceAll(strings.Join(classes, " "), "px-4", "")
+				})),
+			}
+		}
+		opts := append(append([]Option[Props]{
+			Classes(func(p Props) string { return "  first " + p.Custom }),
+		}, options()...), custom()...)
+
+		var customProps []Props
+		for i, p := range props {
+			p.Custom = "custom-" + strconv.Itoa(i%3) + " px-4"
+			customProps = append(customProps, p)
+		}
+		compare(t, New(opts...), New(opts...), customProps)
+	})
+
+	t.Run("too_large", func(t *testing.T) {
+		var opts []Option[Props]
+		for i := range 17 {
+			opts = append(opts, PredicateVariant(
+				func(p Props) bool { return len(p.Custom) > i },
+				"c-"+strconv.Itoa(i),
+			))
+		}
+		c := New(append(opts, Name[Props]("huge"))...)
+
+		if err := c.Compile(); err == nil {
+			t.Errorf("got nil error, want an error")
+		}
+		if got, want := c.Classes(Props{Custom: "ab"}), "c-0 c-1"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+}
@@ -0,0 +1,552 @@
+package cva
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Values is a set of variant values matched by a single position of a compound entry, created
+// with OneOf or AnyValue and used in conjunction with NewCompoundOf and its n-ary counterparts.
+type Values[V comparable] struct {
+	vals []V
+	any  bool
+}
+
+// OneOf creates a Values set matching any of the given values.
+func OneOf[V comparable](vals ...V) Values[V] {
+	return Values[V]{vals: vals}
+}
+
+// AnyValue creates a Values set matching every possible value, i.e. a wildcard.
+func AnyValue[V comparable]() Values[V] {
+	return Values[V]{any: true}
+}
+
+// positionValues returns the values matched by a single position of a compound entry, preferring
+// the supplied set over the exact value when present.
+func positionValues[V comparable](exact V, in *Values[V]) ([]V, bool) {
+	if in == nil {
+		return []V{exact}, false
+	}
+	return in.vals, in.any
+}
+
+func matchesPosition[V comparable](vals []V, any bool, val V) bool {
+	return any || slices.Contains(vals, val)
+}
+
+// NewCompound creates a Compound value for use in CompoundVariant.
+//
+// The v1 and v2 arguments should be the variant values to match against returned by the getter
+// function in CompoundVariant. Each pair of values should be unique, and adding the same pair of
+// values more than once will result in the last occurrence being used.
+func NewCompound[V1 comparable, V2 comparable](
+	v1 V1,
+	v2 V2,
+	classes ...string,
+) Compound[V1, V2] {
+	return Compound[V1, V2]{V1: v1, V2: v2, Classes: classes}
+}
+
+// NewCompoundOf creates a Compound value for use in CompoundVariant that matches sets of values
+// rather than an exact pair. Each position accepts either OneOf or AnyValue.
+//
+// Unlike NewCompound entries, entries created with NewCompoundOf never replace one another. Every
+// entry matching the getter's values is applied, in the order given to CompoundVariant.
+func NewCompoundOf[V1 comparable, V2 comparable](
+	v1 Values[V1],
+	v2 Values[V2],
+	classes ...string,
+) Compound[V1, V2] {
+	return Compound[V1, V2]{Classes: classes, in1: &v1, in2: &v2}
+}
+
+// Compound is a set of variant value pairs and associated class lists,
+// used in conjunction with CompoundVariant.
+type Compound[V1 comparable, V2 comparable] struct {
+	V1      V1
+	V2      V2
+	Classes []string
+
+	in1 *Values[V1]
+	in2 *Values[V2]
+}
+
+func (c Compound[V1, V2]) entry() compoundEntry[pair[V1, V2]] {
+	vals1, any1 := positionValues(c.V1, c.in1)
+	vals2, any2 := positionValues(c.V2, c.in2)
+
+	e := compoundEntry[pair[V1, V2]]{
+		exact:   c.in1 == nil && c.in2 == nil,
+		classes: c.Classes,
+		values:  [][]any{positionSchema(vals1, any1), positionSchema(vals2, any2)},
+	}
+	if any1 || any2 {
+		e.match = func(k pair[V1, V2]) bool {
+			return matchesPosition(vals1, any1, k.V1) && matchesPosition(vals2, any2, k.V2)
+		}
+		return e
+	}
+	for _, v1 := range vals1 {
+		for _, v2 := range vals2 {
+			e.keys = append(e.keys, pair[V1, V2]{v1, v2})
+		}
+	}
+	return e
+}
+
+type pair[V1 comparable, V2 comparable] struct {
+	V1 V1
+	V2 V2
+}
+
+func (k pair[V1, V2]) values() []any {
+	return []any{k.V1, k.V2}
+}
+
+// CompoundVariant defines an inline variant as a set of variant value pairs and associated class
+// lists.
+//
+// The getter function should return a tuple of values corresponding to the compound key. Whenever
+// this exact tuple of values is encountered, the associated class list will be applied.
+//
+// The compounds argument should be a list of Compound values, which can be created using the
+// NewCompound helper for exact pairs, or the NewCompoundOf helper for sets of values and
+// wildcards.
+func CompoundVariant[P any, V1 comparable, V2 comparable](
+	getter func(P) (V1, V2),
+	compounds ...Compound[V1, V2],
+) Option[P] {
+	entries := make([]compoundEntry[pair[V1, V2]], len(compounds))
+	for i, compound := range compounds {
+		entries[i] = compound.entry()
+	}
+
+	return compoundVariant(func(p P) pair[V1, V2] {
+		v1, v2 := getter(p)
+		return pair[V1, V2]{v1, v2}
+	}, 2, entries, nilProblem("CompoundVariant", "getter", getter == nil)...)
+}
+
+// NewCompound3 creates a Compound3 value for use in CompoundVariant3.
+//
+// See NewCompound for details.
+func NewCompound3[V1 comparable, V2 comparable, V3 comparable](
+	v1 V1,
+	v2 V2,
+	v3 V3,
+	classes ...string,
+) Compound3[V1, V2, V3] {
+	return Compound3[V1, V2, V3]{V1: v1, V2: v2, V3: v3, Classes: classes}
+}
+
+// NewCompound3Of creates a Compound3 value for use in CompoundVariant3 that matches sets of values
+// rather than an exact triple.
+//
+// See NewCompoundOf for details.
+func NewCompound3Of[V1 comparable, V2 comparable, V3 comparable](
+	v1 Values[V1],
+	v2 Values[V2],
+	v3 Values[V3],
+	classes ...string,
+) Compound3[V1, V2, V3] {
+	return Compound3[V1, V2, V3]{Classes: classes, in1: &v1, in2: &v2, in3: &v3}
+}
+
+// Compound3 is a set of variant value triples and associated class lists,
+// used in conjunction with CompoundVariant3.
+type Compound3[V1 comparable, V2 comparable, V3 comparable] struct {
+	V1      V1
+	V2      V2
+	V3      V3
+	Classes []string
+
+	in1 *Values[V1]
+	in2 *Values[V2]
+	in3 *Values[V3]
+}
+
+func (c Compound3[V1, V2, V3]) entry() compoundEntry[triple[V1, V2, V3]] {
+	vals1, any1 := positionValues(c.V1, c.in1)
+	vals2, any2 := positionValues(c.V2, c.in2)
+	vals3, any3 := positionValues(c.V3, c.in3)
+
+	e := compoundEntry[triple[V1, V2, V3]]{
+		exact:   c.in1 == nil && c.in2 == nil && c.in3 == nil,
+		classes: c.Classes,
+		values: [][]any{
+			positionSchema(vals1, any1),
+			positionSchema(vals2, any2),
+			positionSchema(vals3, any3),
+		},
+	}
+	if any1 || any2 || any3 {
+		e.match = func(k triple[V1, V2, V3]) bool {
+			return matchesPosition(vals1, any1, k.V1) &&
+				matchesPosition(vals2, any2, k.V2) &&
+				matchesPosition(vals3, any3, k.V3)
+		}
+		return e
+	}
+	for _, v1 := range vals1 {
+		for _, v2 := range vals2 {
+			for _, v3 := range vals3 {
+				e.keys = append(e.keys, triple[V1, V2, V3]{v1, v2, v3})
+			}
+		}
+	}
+	return e
+}
+
+type triple[V1 comparable, V2 comparable, V3 comparable] struct {
+	V1 V1
+	V2 V2
+	V3 V3
+}
+
+func (k triple[V1, V2, V3]) values() []any {
+	return []any{k.V1, k.V2, k.V3}
+}
+
+// CompoundVariant3 defines an inline variant as a set of variant value triples and associated
+// class lists. It behaves identically to CompoundVariant, but for three variant values.
+func CompoundVariant3[P any, V1 comparable, V2 comparable, V3 comparable](
+	getter func(P) (V1, V2, V3),
+	compounds ...Compound3[V1, V2, V3],
+) Option[P] {
+	entries := make([]compoundEntry[triple[V1, V2, V3]], len(compounds))
+	for i, compound := range compounds {
+		entries[i] = compound.entry()
+	}
+
+	return compoundVariant(func(p P) triple[V1, V2, V3] {
+		v1, v2, v3 := getter(p)
+		return triple[V1, V2, V3]{v1, v2, v3}
+	}, 3, entries, nilProblem("CompoundVariant3", "getter", getter == nil)...)
+}
+
+// NewCompound4 creates a Compound4 value for use in CompoundVariant4.
+//
+// See NewCompound for details.
+func NewCompound4[V1 comparable, V2 comparable, V3 comparable, V4 comparable](
+	v1 V1,
+	v2 V2,
+	v3 V3,
+	v4 V4,
+	classes ...string,
+) Compound4[V1, V2, V3, V4] {
+	return Compound4[V1, V2, V3, V4]{V1: v1, V2: v2, V3: v3, V4: v4, Classes: classes}
+}
+
+// NewCompound4Of creates a Compound4 value for use in CompoundVariant4 that matches sets of values
+// rather than an exact quadruple.
+//
+// See NewCompoundOf for details.
+func NewCompound4Of[V1 comparable, V2 comparable, V3 comparable, V4 comparable](
+	v1 Values[V1],
+	v2 Values[V2],
+	v3 Values[V3],
+	v4 Values[V4],
+	classes ...string,
+) Compound4[V1, V2, V3, V4] {
+	return Compound4[V1, V2, V3, V4]{Classes: classes, in1: &v1, in2: &v2, in3: &v3, in4: &v4}
+}
+
+// Compound4 is a set of variant value quadruples and associated class lists,
+// used in conjunction with CompoundVariant4.
+type Compound4[V1 comparable, V2 comparable, V3 comparable, V4 comparable] struct {
+	V1      V1
+	V2      V2
+	V3      V3
+	V4      V4
+	Classes []string
+
+	in1 *Values[V1]
+	in2 *Values[V2]
+	in3 *Values[V3]
+	in4 *Values[V4]
+}
+
+func (c Compound4[V1, V2, V3, V4]) entry() compoundEntry[quad[V1, V2, V3, V4]] {
+	vals1, any1 := positionValues(c.V1, c.in1)
+	vals2, any2 := positionValues(c.V2, c.in2)
+	vals3, any3 := positionValues(c.V3, c.in3)
+	vals4, any4 := positionValues(c.V4, c.in4)
+
+	e := compoundEntry[quad[V1, V2, V3, V4]]{
+		exact:   c.in1 == nil && c.in2 == nil && c.in3 == nil && c.in4 == nil,
+		classes: c.Classes,
+		values: [][]any{
+			positionSchema(vals1, any1),
+			positionSchema(vals2, any2),
+			positionSchema(vals3, any3),
+			positionSchema(vals4, any4),
+		},
+	}
+	if any1 || any2 || any3 || any4 {
+		e.match = func(k quad[V1, V2, V3, V4]) bool {
+			return matchesPosition(vals1, any1, k.V1) &&
+				matchesPosition(vals2, any2, k.V2) &&
+				matchesPosition(vals3, any3, k.V3) &&
+				matchesPosition(vals4, any4, k.V4)
+		}
+		return e
+	}
+	for _, v1 := range vals1 {
+		for _, v2 := range vals2 {
+			for _, v3 := range vals3 {
+				for _, v4 := range vals4 {
+					e.keys = append(e.keys, quad[V1, V2, V3, V4]{v1, v2, v3, v4})
+				}
+			}
+		}
+	}
+	return e
+}
+
+type quad[V1 comparable, V2 comparable, V3 comparable, V4 comparable] struct {
+	V1 V1
+	V2 V2
+	V3 V3
+	V4 V4
+}
+
+func (k quad[V1, V2, V3, V4]) values() []any {
+	return []any{k.V1, k.V2, k.V3, k.V4}
+}
+
+// tuple is the constraint satisfied by the compound key types.
+type tuple interface {
+	comparable
+	values() []any
+}
+
+// CompoundVariant4 defines an inline variant as a set of variant value quadruples and associated
+// class lists. It behaves identically to CompoundVariant, but for four variant values.
+//
+// For more than four values, use MapVariant with a comparable struct as the key.
+func CompoundVariant4[P any, V1 comparable, V2 comparable, V3 comparable, V4 comparable](
+	getter func(P) (V1, V2, V3, V4),
+	compounds ...Compound4[V1, V2, V3, V4],
+) Option[P] {
+	entries := make([]compoundEntry[quad[V1, V2, V3, V4]], len(compounds))
+	for i, compound := range compounds {
+		entries[i] = compound.entry()
+	}
+
+	return compoundVariant(func(p P) quad[V1, V2, V3, V4] {
+		v1, v2, v3, v4 := getter(p)
+		return quad[V1, V2, V3, V4]{v1, v2, v3, v4}
+	}, 4, entries, nilProblem("CompoundVariant4", "getter", getter == nil)...)
+}
+
+// compoundEntry is a single entry of a compound variant, matching either an enumerated list of
+// tuple keys or, when any position is a wildcard, a match function.
+type compoundEntry[K comparable] struct {
+	keys    []K
+	match   func(K) bool
+	exact   bool
+	classes []string
+	values  [][]any
+}
+
+// positionSchema describes the values matched by a single position of a compound entry, nil
+// meaning any value.
+func positionSchema[V comparable](vals []V, wildcard bool) []any {
+	if wildcard {
+		return nil
+	}
+	return append([]any{}, toAny(vals)...)
+}
+
+// compoundSchema describes a compound option from its entries. The known values of each position
+// are the union of the values listed across all entries.
+func compoundSchema[K comparable](entries []compoundEntry[K], positions int) OptionSchema {
+	schema := OptionSchema{
+		Kind:     KindCompound,
+		Variants: make([]VariantSchema, positions),
+		Cases:    make([]CaseSchema, len(entries)),
+	}
+	key := reflect.TypeFor[K]()
+	for pos := range schema.Variants {
+		schema.Variants[pos].typ = key.Field(pos).Type
+	}
+	for i, e := range entries {
+		schema.Cases[i] = CaseSchema{Values: e.values, Classes: e.classes}
+		for pos, vals := range e.values {
+			for _, v := range vals {
+				if !slices.Contains(schema.Variants[pos].Values, v) {
+					schema.Variants[pos].Values = append(schema.Variants[pos].Values, v)
+				}
+			}
+		}
+	}
+	return schema
+}
+
+// compoundVariant is the shared implementation of the CompoundVariant family, looking up the class
+// lists for the tuple key returned by the getter function.
+//
+// Enumerable entries are indexed by key so that lookups stay map-based; only wildcard entries are
+// tested one by one. Exact entries replace earlier exact entries with the same key, which NewE
+// reports as a problem along with the given ones.
+func compoundVariant[P any, K tuple](
+	getter func(P) K,
+	positions int,
+	entries []compoundEntry[K],
+	problems ...string,
+) Option[P] {
+	index := make(map[K][]int)
+	exactAt := make(map[K]int)
+	var wildcards []int
+	joined := make([]string, len(entries))
+
+	for i, e := range entries {
+		joined[i] = JoinClasses(e.classes...)
+		if e.match != nil {
+			wildcards = append(wildcards, i)
+			continue
+		}
+		for _, k := range e.keys {
+			if e.exact {
+				if prev, ok := exactAt[k]; ok {
+					problems = append(problems, duplicateCompoundProblem(k))
+					index[k] = slices.DeleteFunc(index[k], func(j int) bool { return j == prev })
+				}
+				exactAt[k] = i
+			}
+			index[k] = append(index[k], i)
+		}
+	}
+
+	return newOption(describeAs(compoundSchema(entries, positions)), producer[P]{
+		problems: problemsOf(problems),
+		fn: func(p P) []string {
+			key := getter(p)
+			matched := index[key]
+			if len(wildcards) == 0 && len(matched) <= 1 {
+				if len(matched) == 0 {
+					return nil
+				}
+				return entries[matched[0]].classes
+			}
+
+			// Clip so that appending (and sorting) never writes into the shared index.
+			matched = slices.Clip(matched)
+			indexed := len(matched)
+			for _, i := range wildcards {
+				if entries[i].match(key) {
+					matched = append(matched, i)
+				}
+			}
+			if len(matched) > indexed && indexed > 0 {
+				slices.Sort(matched)
+			}
+
+			var classes []string
+			for _, i := range matched {
+				classes = append(classes, entries[i].classes...)
+			}
+			return classes
+		},
+		appendTo: func(dst []byte, p P) []byte {
+			key := getter(p)
+			matched := index[key]
+			// Both matched and wildcards are sorted, so they are merged in place to preserve the
+			// entries' order.
+			for _, w := range wildcards {
+				if !entries[w].match(key) {
+					continue
+				}
+				for len(matched) > 0 && matched[0] < w {
+					dst = appendNormalized(dst, joined[matched[0]])
+					matched = matched[1:]
+				}
+				dst = appendNormalized(dst, joined[w])
+			}
+			for _, i := range matched {
+				dst = appendNormalized(dst, joined[i])
+			}
+			return dst
+		},
+		values: func(p P) []any {
+			return getter(p).values()
+		},
+		enumerate: func() *enumeration[P] {
+			return compoundEnumeration(getter, entries, index, wildcards)
+		},
+	})
+}
+
+// maxCompoundWildcards is the maximum number of wildcard entries of a compound variant for which
+// Compile enumerates every combination of matching entries.
+const maxCompoundWildcards = 8
+
+// compoundEnumeration enumerates the class lists a compound variant can return, one per distinct
+// set of matching entries. Sets of entries are represented as bitmasks, covering those matched by
+// each indexed key along with every combination of wildcard entries.
+func compoundEnumeration[P any, K tuple](
+	getter func(P) K,
+	entries []compoundEntry[K],
+	index map[K][]int,
+	wildcards []int,
+) *enumeration[P] {
+	if len(entries) > 64 || len(wildcards) > maxCompoundWildcards {
+		return nil
+	}
+
+	wildcardMask := func(key K) uint64 {
+		var mask uint64
+		for _, i := range wildcards {
+			if entries[i].match(key) {
+				mask |= 1 << i
+			}
+		}
+		return mask
+	}
+	indexMask := func(key K) uint64 {
+		var mask uint64
+		for _, i := range index[key] {
+			mask |= 1 << i
+		}
+		return mask
+	}
+
+	var masks []uint64
+	for subset := range 1 << len(wildcards) {
+		var mask uint64
+		for bit, i := range wildcards {
+			if subset&(1<<bit) != 0 {
+				mask |= 1 << i
+			}
+		}
+		masks = append(masks, mask)
+	}
+	for _, key := range sortedKeys(index) {
+		masks = append(masks, indexMask(key)|wildcardMask(key))
+	}
+
+	outcomeAt := make(map[uint64]int)
+	var outcomes [][]string
+	for _, mask := range masks {
+		if _, ok := outcomeAt[mask]; ok {
+			continue
+		}
+		var classes []string
+		for i, e := range entries {
+			if mask&(1<<i) != 0 {
+				classes = append(classes, e.classes...)
+			}
+		}
+		outcomeAt[mask] = len(outcomes)
+		outcomes = append(outcomes, classes)
+	}
+
+	return &enumeration[P]{
+		outcomes: outcomes,
+		outcome: func(p P) int {
+			key := getter(p)
+			return outcomeAt[indexMask(key)|wildcardMask(key)]
+		},
+	}
+}
@@ -0,0 +1,293 @@
+package cva
+
+import (
+	"testing"
+)
+
+func TestCompoundVariant(t *testing.T) {
+	t.Run("duplicate_pairs", func(t *testing.T) {
+		type Props struct {
+			Size  string
+			Color string
+		}
+
+		button := New(
+			CompoundVariant(
+				func(p Props) (string, string) { return p.Size, p.Color },
+				NewCompound("small", "red", "first"),
+				NewCompound("small", "red", "last"),
+			),
+		)
+
+		got := button.Classes(Props{Size: "small", Color: "red"})
+		want := "last"
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("value_sets", func(t *testing.T) {
+		type Props struct {
+			Size     string
+			Style    string
+			Disabled bool
+		}
+
+		button := New(
+			Base[Props]("button"),
+			CompoundVariant(
+				func(p Props) (string, string) { return p.Size, p.Style },
+				NewCompoundOf(OneOf("small", "medium"), OneOf("icon"), "[&_svg]:size-4"),
+				NewCompound("large", "icon", "[&_svg]:size-6"),
+				NewCompoundOf(AnyValue[string](), OneOf("link", "ghost"), "bg-transparent"),
+				NewCompoundOf(OneOf("small"), AnyValue[string](), "text-sm"),
+			),
+			CompoundVariant(
+				func(p Props) (string, bool) { return p.Style, p.Disabled },
+				NewCompoundOf(OneOf("icon"), OneOf(true), "opacity-50"),
+				NewCompoundOf(AnyValue[string](), AnyValue[bool](), "any"),
+			),
+		)
+
+		tests := []struct {
+			name  string
+			props Props
+			want  string
+		}{
+			{
+				name:  "small-icon",
+				props: Props{Size: "small", Style: "icon"},
+				want:  "button [&_svg]:size-4 text-sm any",
+			},
+			{
+				name:  "medium-icon-disabled",
+				props: Props{Size: "medium", Style: "icon", Disabled: true},
+				want:  "button [&_svg]:size-4 opacity-50 any",
+			},
+			{
+				name:  "large-icon",
+				props: Props{Size: "large", Style: "icon"},
+				want:  "button [&_svg]:size-6 any",
+			},
+			{
+				name:  "large-link",
+				props: Props{Size: "large", Style: "link"},
+				want:  "button bg-transparent any",
+			},
+			{
+				name:  "small-ghost",
+				props: Props{Size: "small", Style: "ghost"},
+				want:  "button bg-transparent text-sm any",
+			},
+			{
+				name:  "unknown",
+				props: Props{Size: "huge", Style: "regular"},
+				want:  "button any",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := button.Classes(test.props)
+				if got != test.want {
+					t.Errorf("got %s, want %s", got, test.want)
+				}
+			})
+		}
+	})
+
+	t.Run("empty_set", func(t *testing.T) {
+		type Props struct {
+			Size  string
+			Style string
+		}
+
+		button := New(
+			CompoundVariant(
+				func(p Props) (string, string) { return p.Size, p.Style },
+				NewCompoundOf(OneOf[string](), AnyValue[string](), "never"),
+			),
+		)
+
+		if got := button.Classes(Props{}); got != "" {
+			t.Errorf("got %s, want empty string", got)
+		}
+	})
+
+	t.Run("sets_do_not_replace", func(t *testing.T) {
+		type Props struct {
+			Size  string
+			Style string
+		}
+
+		button := New(
+			CompoundVariant(
+				func(p Props) (string, string) { return p.Size, p.Style },
+				NewCompound("small", "icon", "first"),
+				NewCompoundOf(OneOf("small"), OneOf("icon"), "second"),
+				NewCompound("small", "icon", "third"),
+			),
+		)
+
+		got := button.Classes(Props{Size: "small", Style: "icon"})
+		want := "second third"
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("CompoundVariant3", func(t *testing.T) {
+		type Props struct {
+			Size     string
+			Color    string
+			Disabled bool
+		}
+
+		button := New(
+			Base[Props]("button"),
+			CompoundVariant3(
+				func(p Props) (string, string, bool) { return p.Size, p.Color, p.Disabled },
+				NewCompound3("small", "red", false, "small-red"),
+				NewCompound3("small", "red", true, "small-red-disabled"),
+				NewCompound3("large", "blue", true, "large-blue-disabled"),
+				NewCompound3Of(
+					OneOf("large"),
+					AnyValue[string](),
+					OneOf(false, true),
+					"large",
+				),
+			),
+		)
+
+		tests := []struct {
+			name  string
+			props Props
+			want  string
+		}{
+			{
+				name:  "small-red",
+				props: Props{Size: "small", Color: "red"},
+				want:  "button small-red",
+			},
+			{
+				name:  "small-red-disabled",
+				props: Props{Size: "small", Color: "red", Disabled: true},
+				want:  "button small-red-disabled",
+			},
+			{
+				name:  "large-blue-disabled",
+				props: Props{Size: "large", Color: "blue", Disabled: true},
+				want:  "button large-blue-disabled large",
+			},
+			{
+				name:  "large-green",
+				props: Props{Size: "large", Color: "green"},
+				want:  "button large",
+			},
+			{
+				name:  "unknown-combination",
+				props: Props{Size: "medium", Color: "blue"},
+				want:  "button",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := button.Classes(test.props)
+				if got != test.want {
+					t.Errorf("got %s, want %s", got, test.want)
+				}
+			})
+		}
+	})
+
+	t.Run("CompoundVariant4", func(t *testing.T) {
+		type Props struct {
+			Size     string
+			Color    string
+			Disabled bool
+			Weight   int
+		}
+
+		button := New(
+			Base[Props]("button"),
+			CompoundVariant4(
+				func(p Props) (string, string, bool, int) {
+					return p.Size, p.Color, p.Disabled, p.Weight
+				},
+				NewCompound4("small", "red", false, 400, "small-red-400"),
+				NewCompound4("small", "red", false, 700, "small-red-700"),
+				NewCompound4("small", "red", false, 700, "small-red-bold"),
+				NewCompound4Of(
+					AnyValue[string](),
+					AnyValue[string](),
+					OneOf(true),
+					OneOf(700, 900),
+					"disabled-bold",
+				),
+			),
+		)
+
+		tests := []struct {
+			name  string
+			props Props
+			want  string
+		}{
+			{
+				name:  "small-red-400",
+				props: Props{Size: "small", Color: "red", Weight: 400},
+				want:  "button small-red-400",
+			},
+			{
+				name:  "duplicate",
+				props: Props{Size: "small", Color: "red", Weight: 700},
+				want:  "button small-red-bold",
+			},
+			{
+				name:  "disabled-bold",
+				props: Props{Size: "large", Color: "blue", Disabled: true, Weight: 900},
+				want:  "button disabled-bold",
+			},
+			{
+				name:  "unknown-combination",
+				props: Props{Size: "small", Color: "red", Disabled: true, Weight: 400},
+				want:  "button",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := button.Classes(test.props)
+				if got != test.want {
+					t.Errorf("got %s, want %s", got, test.want)
+				}
+			})
+		}
+	})
+
+	t.Run("struct_key", func(t *testing.T) {
+		type Props struct {
+			A, B, C, D, E int
+		}
+
+		type key struct {
+			A, B, C, D, E int
+		}
+
+		widget := New(
+			MapVariant(
+				func(p Props) key { return key(p) },
+				map[key]string{
+					{1, 2, 3, 4, 5}: "one-to-five",
+				},
+			),
+		)
+
+		if got := widget.Classes(Props{1, 2, 3, 4, 5}); got != "one-to-five" {
+			t.Errorf("got %s, want %s", got, "one-to-five")
+		}
+		if got := widget.Classes(Props{1, 2, 3, 4, 6}); got != "" {
+			t.Errorf("got %s, want empty string", got)
+		}
+	})
+}
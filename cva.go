@@ -1,23 +1,262 @@
 package cva
 
 import (
+	"iter"
+	"reflect"
 	"slices"
+	"sync"
 )
 
 // Cva is a class name generator for a component.
 //
 // The P type parameter is the type of the component's props.
 type Cva[P any] struct {
-	producers []func(P) []string
+	name     string
+	defaults []func(P) P
+	// defaultInfo describes each of the functions in defaults, for the component's Schema.
+	defaultInfo []DefaultSchema
+	producers   []producer[P]
+	slots       []string
+	merger      Merger
+	hasMerger   bool
+	cache       resultCache[P]
+	compiled    map[string]*compiledSlot[P]
+	problems    []DefinitionProblem
+}
+
+// producer is a single class list generator, targeting either the root element (an empty slot
+// name) or one of the component's named slots.
+type producer[P any] struct {
+	slot string
+	fn   func(P) []string
+	// appendTo appends the producer's classes to dst, each preceded by a single space, without
+	// allocating for options whose class lists are known in advance.
+	appendTo func(dst []byte, p P) []byte
+	info     *OptionSchema
+	// problems, if set, returns the problems found in the option's definition, reported by NewE.
+	problems func() []string
+	// validate, if set, returns the variant values read by the producer which are outside of
+	// their known domain, for ClassesE and SlotsE.
+	validate func(P) []UnknownValue
+	// values, if set, returns the variant values the producer's output depends on, for Explain.
+	values func(P) []any
+	// trace, if set, replaces the default tracing of the producer's output for Explain.
+	trace func(P) ([]string, Origin)
+	// enumerate, if set, describes the finite set of class lists the producer can return, for
+	// Compile. It may return nil if the set is too large to enumerate.
+	enumerate func() *enumeration[P]
+	// use, if set, is called when the option is applied to a component, freezing the configuration
+	// of the variants the producer reads.
+	use func()
 }
 
 // Classes generates the class list for the component based on the props.
+//
+// For multi-part components, this is the class list for the root element, i.e. everything not
+// targeted at a named slot with InSlot or InSlots. Use Slots to get the class lists for all slots.
 func (c *Cva[P]) Classes(props P) string {
-	parts := make([]string, 0)
+	return c.cachedSlotClasses(props, "")
+}
+
+// cachedSlotClasses generates the class list of the slot for the props, which are normalized if
+// needed, going through the component's cache if it has one.
+func (c *Cva[P]) cachedSlotClasses(props P, slot string) string {
+	if c.cache == nil {
+		return c.slotClasses(c.normalize(props), slot)
+	}
+	if classes, ok := c.cache.get(props, slot); ok {
+		return classes
+	}
+	classes := c.slotClasses(c.normalize(props), slot)
+	c.cache.put(props, slot, classes)
+	return classes
+}
+
+// Slots generates the class lists for every slot of the component based on the props.
+//
+// The root element's class list is stored under the empty slot name, and is identical to the
+// output of Classes.
+func (c *Cva[P]) Slots(props P) SlotClasses {
+	classes := make(SlotClasses, len(c.slots)+1)
+	if c.cache != nil {
+		var normalized P
+		var isNormalized bool
+		for _, slot := range append([]string{""}, c.slots...) {
+			if cached, ok := c.cache.get(props, slot); ok {
+				classes[slot] = cached
+				continue
+			}
+			if !isNormalized {
+				normalized, isNormalized = c.normalize(props), true
+			}
+			classes[slot] = c.slotClasses(normalized, slot)
+			c.cache.put(props, slot, classes[slot])
+		}
+		return classes
+	}
+
+	props = c.normalize(props)
+	classes[""] = c.slotClasses(props, "")
+	for _, slot := range c.slots {
+		classes[slot] = c.slotClasses(props, slot)
+	}
+	return classes
+}
+
+func (c *Cva[P]) slotClasses(props P, slot string) string {
+	if cs, ok := c.compiled[slot]; ok {
+		return cs.classes(c, props)
+	}
+
+	buf := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(buf)
+	*buf = c.appendSlot((*buf)[:0], props, slot)
+	if merger := c.activeMerger(); merger != nil {
+		return JoinClasses(merger.Merge(string(*buf)))
+	}
+	return string(*buf)
+}
+
+// bufferPool holds the byte buffers class lists are built in before being converted to strings.
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 256)
+		return &buf
+	},
+}
+
+// appendSlot appends the normalized, unmerged class list of the slot for the already normalized
+// props to dst.
+func (c *Cva[P]) appendSlot(dst []byte, props P, slot string) []byte {
+	start := len(dst)
 	for _, producer := range c.producers {
-		parts = append(parts, producer(props)...)
+		if producer.slot == slot {
+			dst = producer.appendTo(dst, props)
+		}
+	}
+	return trimSeparator(dst, start)
+}
+
+// AppendClasses appends the class list generated for the props, as returned by Classes, to dst
+// and returns the extended buffer.
+//
+// Unless the component has a merger, building the class list does not allocate beyond growing
+// dst, for every option except Classes, whose getters allocate on their own. A separating space
+// is not added before the class list, even when dst is not empty.
+func (c *Cva[P]) AppendClasses(dst []byte, props P) []byte {
+	return c.AppendSlotClasses(dst, props, "")
+}
+
+// AppendSlotClasses is like AppendClasses, but appends the class list of the given slot, as
+// returned by Slots, instead of the root element's.
+func (c *Cva[P]) AppendSlotClasses(dst []byte, props P, slot string) []byte {
+	if c.cache != nil {
+		return append(dst, c.cachedSlotClasses(props, slot)...)
+	}
+
+	props = c.normalize(props)
+	merger := c.activeMerger()
+	if cs, ok := c.compiled[slot]; ok && (cs.table != nil || merger == nil) {
+		return cs.appendTo(dst, props)
+	}
+	if merger == nil {
+		return c.appendSlot(dst, props, slot)
 	}
-	return JoinClasses(parts...)
+	classes := c.slotClasses(props, slot)
+	return append(dst, classes...)
+}
+
+// absorb carries over the component-wide configuration of an inner Cva, as built by wrapping
+// options like InSlot and Label.
+func (c *Cva[P]) absorb(inner *Cva[P]) {
+	c.defaults = append(c.defaults, inner.defaults...)
+	c.defaultInfo = append(c.defaultInfo, inner.defaultInfo...)
+	for _, p := range inner.problems {
+		c.addProblems(p.File, p.Line, p.Message)
+	}
+	if inner.hasMerger {
+		c.merger = inner.merger
+		c.hasMerger = true
+	}
+	if inner.cache != nil {
+		c.cache = inner.cache
+	}
+}
+
+// normalize applies all the component's default variants to the props.
+func (c *Cva[P]) normalize(props P) P {
+	return applyDefaults(c.defaults, props)
+}
+
+// applyDefaults applies the given default variant functions to the props, in order.
+func applyDefaults[P any](defaults []func(P) P, props P) P {
+	for _, fn := range defaults {
+		props = fn(props)
+	}
+	return props
+}
+
+// withDefaults returns a copy of the producer applying the given default variant functions to the
+// props before computing its output, after the component's own defaults.
+func (p producer[P]) withDefaults(defaults []func(P) P) producer[P] {
+	if len(defaults) == 0 {
+		return p
+	}
+	normalize := func(props P) P {
+		return applyDefaults(defaults, props)
+	}
+
+	orig := p
+	p.fn = func(props P) []string {
+		return orig.fn(normalize(props))
+	}
+	p.appendTo = func(dst []byte, props P) []byte {
+		return orig.appendTo(dst, normalize(props))
+	}
+	if orig.validate != nil {
+		p.validate = func(props P) []UnknownValue {
+			return orig.validate(normalize(props))
+		}
+	}
+	if orig.values != nil {
+		p.values = func(props P) []any {
+			return orig.values(normalize(props))
+		}
+	}
+	p.trace = func(props P) ([]string, Origin) {
+		return orig.explain(normalize(props))
+	}
+	if orig.enumerate != nil {
+		p.enumerate = func() *enumeration[P] {
+			e := orig.enumerate()
+			if e == nil {
+				return nil
+			}
+			return &enumeration[P]{
+				outcomes: e.outcomes,
+				outcome: func(props P) int {
+					return e.outcome(normalize(props))
+				},
+			}
+		}
+	}
+	return p
+}
+
+func (c *Cva[P]) addProducer(p producer[P]) {
+	if p.slot != "" && !slices.Contains(c.slots, p.slot) {
+		c.slots = append(c.slots, p.slot)
+	}
+	c.producers = append(c.producers, p)
+}
+
+// SlotClasses holds the generated class lists for each slot of a multi-part component, keyed by
+// slot name. The root element's class list is stored under the empty slot name.
+type SlotClasses map[string]string
+
+// Get returns the class list for the given slot, or an empty string if the slot is not defined.
+func (s SlotClasses) Get(slot string) string {
+	return s[slot]
 }
 
 // New creates a new Cva instance.
@@ -32,32 +271,126 @@ func New[P any](opts ...Option[P]) *Cva[P] {
 // Option is a function that configures a Cva instance.
 type Option[P any] func(*Cva[P])
 
+// newOption creates an Option adding a single producer described by the schema returned by
+// describe. The schema is built every time the option is applied, so that wrapping options like
+// InSlot and Label can safely modify it. Applying the option freezes the configuration of the
+// variants it depends on. The location of the option's construction in the calling code is
+// recorded in the schema. Producers without an appendTo function append the normalized output of
+// fn.
+func newOption[P any](describe func() OptionSchema, p producer[P]) Option[P] {
+	file, line := callerLocation()
+	if p.appendTo == nil {
+		fn := p.fn
+		p.appendTo = func(dst []byte, props P) []byte {
+			for _, classes := range fn(props) {
+				dst = appendTokens(dst, classes)
+			}
+			return dst
+		}
+	}
+	return func(c *Cva[P]) {
+		if p.use != nil {
+			p.use()
+		}
+		info := describe()
+		info.File, info.Line = file, line
+		if p.problems != nil {
+			c.addProblems(file, line, p.problems()...)
+		}
+		added := p
+		added.info = &info
+		c.addProducer(added)
+	}
+}
+
 // Classes applies all the classes returned from the supplied getter function.
-func Classes[P any, S string | []string](fn func(P) S) Option[P] {
+//
+// The getter may return a class list, a slice of class lists or an iter.Seq of class lists, e.g.
+// one produced by another component's Tokens method.
+func Classes[P any, S string | []string | iter.Seq[string]](fn func(P) S) Option[P] {
 	var nFn func(P) []string
-	if sliceFn, ok := any(fn).(func(P) []string); ok {
-		nFn = sliceFn
-	} else {
+	var appendTo func([]byte, P) []byte
+	switch fn := any(fn).(type) {
+	case func(P) []string:
+		nFn = fn
+	case func(P) string:
+		nFn = func(p P) []string {
+			return []string{fn(p)}
+		}
+		appendTo = func(dst []byte, p P) []byte {
+			return appendTokens(dst, fn(p))
+		}
+	case func(P) iter.Seq[string]:
 		nFn = func(p P) []string {
-			return []string{any(fn).(func(P) string)(p)}
+			return slices.Collect(fn(p))
+		}
+		appendTo = func(dst []byte, p P) []byte {
+			for classes := range fn(p) {
+				dst = appendTokens(dst, classes)
+			}
+			return dst
 		}
 	}
 
+	return newOption(describeAs(OptionSchema{Kind: KindClasses}), producer[P]{
+		fn:       nFn,
+		appendTo: appendTo,
+		problems: problemsOf(nilProblem("Classes", "getter", fn == nil)),
+	})
+}
+
+// DefaultVariants defines a normalization function that is applied to the props before any of the
+// component's options are evaluated, regardless of where it appears in the option list. This
+// allows every option (inline variants, Variant-based matchers and inherited options alike) to
+// see the same defaulted values.
+//
+// When multiple DefaultVariants options are supplied, they are applied in the order given. Within
+// InSlot or InSlots, the function only applies to the options of the targeted slots.
+func DefaultVariants[P any](fn func(P) P) Option[P] {
+	file, line := callerLocation()
 	return func(c *Cva[P]) {
-		c.producers = append(c.producers, nFn)
+		if fn == nil {
+			c.addProblems(file, line, nilProblem("DefaultVariants", "normalization function", true)...)
+			return
+		}
+		c.defaults = append(c.defaults, fn)
+		c.defaultInfo = append(c.defaultInfo, DefaultSchema{File: file, Line: line})
 	}
 }
 
+// DefaultVariant defines a default value for a single prop. The field function should return a
+// pointer to the prop's field within the supplied props, which will be set to val whenever it
+// holds its zero value.
+//
+// See DefaultVariants for details on when defaults are applied.
+func DefaultVariant[P any, V comparable](field func(*P) *V, val V) Option[P] {
+	if field == nil {
+		file, line := callerLocation()
+		return func(c *Cva[P]) {
+			c.addProblems(file, line, nilProblem("DefaultVariant", "field function", true)...)
+		}
+	}
+	return DefaultVariants(func(p P) P {
+		var zero V
+		if ptr := field(&p); *ptr == zero {
+			*ptr = val
+		}
+		return p
+	})
+}
+
 // Static defines a static class list for the component to be applied regardless of the component's
 // props.
 func Static[P any](classes ...string) Option[P] {
-	return Classes(func(P) []string { return classes })
+	classes = slices.Clone(classes)
+	return newOption(describeAs(OptionSchema{Kind: KindBase, Classes: classes}), staticProducer[P](classes))
 }
 
 // Base defines a static class list for the component to be applied regardless of the component's
 // props. Alias for Static, and included for consistency with the original cva API.
 func Base[P any](classes ...string) Option[P] {
-	return Classes(func(P) []string { return classes })
+	classes = slices.Clone(classes)
+	return newOption(describeAs(OptionSchema{Kind: KindBase, Classes: classes}), staticProducer[P](classes))
 }
 
 // MapVariant defines an inline variant as a map of values to class lists.
@@ -79,65 +412,82 @@ func MapVariant[P any, V comparable, S string | []string](
 		}
 	}
 
-	return Classes(func(p P) []string {
-		key := getter(p)
-		if classes, ok := nMap[key]; ok {
-			return classes
-		}
-		return nil
-	})
+	p := mapProducer(getter, nMap)
+	p.validate = checkKnown(getter, sortedKeys(nMap), "", 0)
+	p.problems = problemsOf(nilProblem("MapVariant", "getter", getter == nil))
+	return newOption(describeAs(mapSchema(VariantSchema{}, nMap)), p)
 }
 
-// NewCompound creates a Compound value for use in CompoundVariant.
-//
-// The v1 and v2 arguments should be the variant values to match against returned by the getter
-// function in CompoundVariant. Each pair of values should be unique, and adding the same pair of
-// values more than once will result in the last occurrence being used.
-func NewCompound[V1 comparable, V2 comparable](
-	v1 V1,
-	v2 V2,
-	classes ...string,
-) Compound[V1, V2] {
-	return Compound[V1, V2]{V1: v1, V2: v2, Classes: classes}
+// staticProducer returns a producer always applying the given classes.
+func staticProducer[P any](classes []string) producer[P] {
+	joined := JoinClasses(classes...)
+	return producer[P]{
+		fn:       func(P) []string { return classes },
+		appendTo: func(dst []byte, _ P) []byte { return appendNormalized(dst, joined) },
+		enumerate: func() *enumeration[P] {
+			return &enumeration[P]{
+				outcomes: [][]string{classes},
+				outcome:  func(P) int { return 0 },
+			}
+		},
+	}
 }
 
-// Compound is a set of variant value pairs and associated class lists,
-// used in conjunction with CompoundVariant.
-type Compound[V1 comparable, V2 comparable] struct {
-	V1      V1
-	V2      V2
-	Classes []string
+// mapProducer returns a producer applying the class list mapped to the getter's value, if any.
+func mapProducer[P any, V comparable](getter func(P) V, classesMap map[V][]string) producer[P] {
+	joined := make(map[V]string, len(classesMap))
+	for k, classes := range classesMap {
+		joined[k] = JoinClasses(classes...)
+	}
+	return producer[P]{
+		fn: func(p P) []string {
+			if classes, ok := classesMap[getter(p)]; ok {
+				return classes
+			}
+			return nil
+		},
+		appendTo: func(dst []byte, p P) []byte {
+			return appendNormalized(dst, joined[getter(p)])
+		},
+		values: func(p P) []any { return []any{getter(p)} },
+		enumerate: func() *enumeration[P] {
+			keys := sortedKeys(classesMap)
+			index := make(map[V]int, len(keys))
+			outcomes := make([][]string, len(keys)+1)
+			for i, k := range keys {
+				index[k] = i
+				outcomes[i] = classesMap[k]
+			}
+			return &enumeration[P]{
+				outcomes: outcomes,
+				outcome: func(p P) int {
+					if i, ok := index[getter(p)]; ok {
+						return i
+					}
+					return len(keys)
+				},
+			}
+		},
+	}
 }
 
-type pair[V1 comparable, V2 comparable] struct {
-	V1 V1
-	V2 V2
-}
+// mapSchema describes a map option for the given variant. The variant's known values default to
+// the map's keys.
+func mapSchema[V comparable](variant VariantSchema, classesMap map[V][]string) OptionSchema {
+	keys := sortedKeys(classesMap)
+	if variant.Values == nil {
+		variant.Values = toAny(keys)
+	}
+	if variant.typ == nil {
+		variant.typ = reflect.TypeFor[V]()
+	}
 
-// CompoundVariant defines an inline variant as a set of variant value pairs and associated class
-// lists.
-//
-// The getter function should return a tuple of values corresponding to the compound key. Whenever
-// this exact tuple of values is encountered, the associated class list will be applied.
-//
-// The compounds argument should be a list of Compound values, which can be created using the
-// NewCompound helper.
-func CompoundVariant[P any, V1 comparable, V2 comparable](
-	getter func(P) (V1, V2),
-	compounds ...Compound[V1, V2],
-) Option[P] {
-	classesMap := make(map[pair[V1, V2]][]string)
-	for _, compound := range compounds {
-		classesMap[pair[V1, V2]{compound.V1, compound.V2}] = compound.Classes
+	cases := make([]CaseSchema, len(keys))
+	for i, k := range keys {
+		cases[i] = CaseSchema{Values: [][]any{{k}}, Classes: classesMap[k]}
 	}
 
-	return Classes(func(p P) []string {
-		v1, v2 := getter(p)
-		if classes, ok := classesMap[pair[V1, V2]{v1, v2}]; ok {
-			return classes
-		}
-		return nil
-	})
+	return OptionSchema{Kind: KindMap, Variants: []VariantSchema{variant}, Cases: cases}
 }
 
 // PredicateVariant defines an inline variant that applies a class list based on a predicate
@@ -146,11 +496,66 @@ func PredicateVariant[P any](
 	test func(P) bool,
 	classes ...string,
 ) Option[P] {
-	return Classes(func(p P) []string {
-		if test(p) {
-			return classes
+	return predicateOption(
+		func() Condition { return Condition{Op: OpFunc} },
+		test,
+		nil,
+		classes,
+		problemsOf(nilProblem("PredicateVariant", "predicate", test == nil)),
+		nil,
+	)
+}
+
+// predicateOption creates an Option applying the classes whenever the test passes, described by
+// the condition returned by cond and checking the variant values it reads with validate, if not
+// nil. The problems function, if not nil, returns the problems found in the option's definition,
+// reported by NewE.
+func predicateOption[P any](
+	cond func() Condition,
+	test func(P) bool,
+	validate func(P) []UnknownValue,
+	classes []string,
+	problems func() []string,
+	use func(),
+) Option[P] {
+	classes = slices.Clone(classes)
+	describe := func() OptionSchema {
+		cond := cond()
+		return OptionSchema{
+			Kind:      KindPredicate,
+			Variants:  cond.variants(),
+			Classes:   classes,
+			Condition: &cond,
 		}
-		return nil
+	}
+	joined := JoinClasses(classes...)
+	return newOption(describe, producer[P]{
+		validate: validate,
+		problems: problems,
+		use:      use,
+		fn: func(p P) []string {
+			if test(p) {
+				return classes
+			}
+			return nil
+		},
+		appendTo: func(dst []byte, p P) []byte {
+			if test(p) {
+				return appendNormalized(dst, joined)
+			}
+			return dst
+		},
+		enumerate: func() *enumeration[P] {
+			return &enumeration[P]{
+				outcomes: [][]string{nil, classes},
+				outcome: func(p P) int {
+					if test(p) {
+						return 1
+					}
+					return 0
+				},
+			}
+		},
 	})
 }
 
@@ -159,14 +564,108 @@ func PredicateVariant[P any](
 // The base argument is the Cva instance to inherit from. The props argument is a function that
 // maps the new props type to the base props type, so that it can be passed to all the base Cva's
 // producers.
+//
+// Any slots defined on the base Cva are carried over to the new Cva, so the inherited classes
+// continue to target the same slots. Default variants defined on the base Cva are applied to the
+// mapped props before they are passed to the base Cva's producers. The base Cva's merger is used
+// unless the new Cva configures its own with WithMerger.
 func Inherit[P any, B any](base *Cva[B], baseMapper func(P) B) Option[P] {
+	file, line := callerLocation()
 	return func(c *Cva[P]) {
-		mapped := make([]func(P) []string, len(base.producers))
-		for i, producer := range base.producers {
-			mapped[i] = func(p P) []string {
-				return producer(baseMapper(p))
+		if base == nil || baseMapper == nil {
+			c.addProblems(file, line, slices.Concat(
+				nilProblem("Inherit", "base component", base == nil),
+				nilProblem("Inherit", "props mapper", baseMapper == nil),
+			)...)
+			if base == nil {
+				return
+			}
+		}
+		if !c.hasMerger && base.hasMerger {
+			c.merger = base.merger
+			c.hasMerger = true
+		}
+
+		baseSchema := base.Schema()
+		info := &OptionSchema{Kind: KindInherit, Inherited: &baseSchema, File: file, Line: line}
+		for _, bp := range base.producers {
+			c.addProducer(producer[P]{
+				slot: bp.slot,
+				fn: func(p P) []string {
+					return bp.fn(base.normalize(baseMapper(p)))
+				},
+				appendTo: func(dst []byte, p P) []byte {
+					return bp.appendTo(dst, base.normalize(baseMapper(p)))
+				},
+				validate: func(p P) []UnknownValue {
+					return bp.unknownValues(base.normalize(baseMapper(p)))
+				},
+				info: info,
+				trace: func(p P) ([]string, Origin) {
+					classes, origin := bp.explain(base.normalize(baseMapper(p)))
+					origin.Inherited = append([]string{base.name}, origin.Inherited...)
+					return classes, origin
+				},
+				enumerate: func() *enumeration[P] {
+					if bp.enumerate == nil {
+						return nil
+					}
+					e := bp.enumerate()
+					if e == nil {
+						return nil
+					}
+					return &enumeration[P]{
+						outcomes: e.outcomes,
+						outcome: func(p P) int {
+							return e.outcome(base.normalize(baseMapper(p)))
+						},
+					}
+				},
+			})
+		}
+	}
+}
+
+// InSlot applies the given options to a named slot of a multi-part component rather than to the
+// root element.
+//
+// Any option can be targeted at a slot, including Base, MapVariant, CompoundVariant, Matcher.Then
+// and Inherit. Options that already target a named slot (i.e. nested InSlot calls or inherited
+// slots) keep their original slot.
+//
+// Default variants defined within the slot with DefaultVariant or DefaultVariants only apply to the
+// slot's options, after the component's own default variants, and do not change the props seen by
+// the root element or other slots.
+func InSlot[P any](slot string, opts ...Option[P]) Option[P] {
+	return InSlots([]string{slot}, opts...)
+}
+
+// InSlots applies the given options to each of the named slots of a multi-part component. See
+// InSlot for details.
+func InSlots[P any](slots []string, opts ...Option[P]) Option[P] {
+	return func(c *Cva[P]) {
+		inner := New(opts...)
+		defaults := inner.defaults
+		inner.defaults = nil
+		for i := range inner.defaultInfo {
+			if len(inner.defaultInfo[i].Slots) == 0 {
+				inner.defaultInfo[i].Slots = slices.Clone(slots)
+			}
+		}
+		c.absorb(inner)
+		for _, p := range inner.producers {
+			p = p.withDefaults(defaults)
+			if p.slot != "" {
+				c.addProducer(p)
+				continue
+			}
+			if len(p.info.Slots) == 0 {
+				p.info.Slots = slots
+			}
+			for _, slot := range slots {
+				p.slot = slot
+				c.addProducer(p)
 			}
 		}
-		c.producers = append(c.producers, mapped...)
 	}
 }
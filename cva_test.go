@@ -1,6 +1,7 @@
 package cva
 
 import (
+	"slices"
 	"strconv"
 	"testing"
 )
@@ -665,6 +666,372 @@ func TestCva(t *testing.T) {
 		}
 	})
 
+	t.Run("DefaultVariants", func(t *testing.T) {
+		type Props struct {
+			Size  string
+			Style string
+		}
+
+		style := NewVariant(func(p Props) string { return p.Style })
+
+		button := New(
+			Base[Props]("button"),
+			MapVariant(
+				func(p Props) string { return p.Size },
+				map[string]string{"small": "button-small", "medium": "button-medium"},
+			),
+			CompoundVariant(
+				func(p Props) (string, string) { return p.Size, p.Style },
+				NewCompound("medium", "primary", "medium-primary"),
+			),
+			style.Is("primary").Then("button-primary"),
+			PredicateVariant(func(p Props) bool { return p.Style == "" }, "unstyled"),
+			// Defaults apply to all options, even when declared last
+			DefaultVariant(func(p *Props) *string { return &p.Size }, "medium"),
+			DefaultVariants(func(p Props) Props {
+				if p.Style == "" {
+					p.Style = "primary"
+				}
+				return p
+			}),
+		)
+
+		tests := []struct {
+			name  string
+			props Props
+			want  string
+		}{
+			{
+				name:  "all-defaults",
+				props: Props{},
+				want:  "button button-medium medium-primary button-primary",
+			},
+			{
+				name:  "default-style",
+				props: Props{Size: "small"},
+				want:  "button button-small button-primary",
+			},
+			{
+				name:  "default-size",
+				props: Props{Style: "secondary"},
+				want:  "button button-medium",
+			},
+			{
+				name:  "no-defaults",
+				props: Props{Size: "small", Style: "secondary"},
+				want:  "button button-small",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := button.Classes(test.props)
+				if got != test.want {
+					t.Errorf("got %s, want %s", got, test.want)
+				}
+			})
+		}
+
+		t.Run("order", func(t *testing.T) {
+			counter := New(
+				DefaultVariant(func(p *Props) *string { return &p.Size }, "small"),
+				DefaultVariants(func(p Props) Props {
+					p.Size += "-second"
+					return p
+				}),
+				Classes(func(p Props) string { return p.Size }),
+			)
+
+			got := counter.Classes(Props{})
+			want := "small-second"
+			if got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+
+		t.Run("slots", func(t *testing.T) {
+			card := New(
+				InSlot("header",
+					DefaultVariant(func(p *Props) *string { return &p.Size }, "small"),
+					MapVariant(
+						func(p Props) string { return p.Size },
+						map[string]string{"small": "header-small"},
+					),
+				),
+				MapVariant(
+					func(p Props) string { return p.Size },
+					map[string]string{"small": "card-small"},
+				),
+			)
+
+			got := card.Slots(Props{})
+			if got.Get("") != "" {
+				t.Errorf("root: got %s, want %s", got.Get(""), "")
+			}
+			if got.Get("header") != "header-small" {
+				t.Errorf("header: got %s, want %s", got.Get("header"), "header-small")
+			}
+
+			got = card.Slots(Props{Size: "small"})
+			if got.Get("") != "card-small" {
+				t.Errorf("root: got %s, want %s", got.Get(""), "card-small")
+			}
+
+			defaults := card.Schema().Defaults
+			if len(defaults) != 1 || !slices.Equal(defaults[0].Slots, []string{"header"}) {
+				t.Errorf("schema defaults: got %v, want defaults for slot header", defaults)
+			}
+
+			if err := card.Compile(); err != nil {
+				t.Fatal(err)
+			}
+			got = card.Slots(Props{})
+			if got.Get("") != "" || got.Get("header") != "header-small" {
+				t.Errorf("compiled: got %s and %s, want %s and %s", got.Get(""), got.Get("header"), "",
+					"header-small")
+			}
+		})
+
+		t.Run("slot_after_component", func(t *testing.T) {
+			card := New(
+				DefaultVariant(func(p *Props) *string { return &p.Size }, "large"),
+				InSlot("header",
+					DefaultVariant(func(p *Props) *string { return &p.Size }, "small"),
+					MapVariant(
+						func(p Props) string { return p.Size },
+						map[string]string{"small": "header-small", "large": "header-large"},
+					),
+				),
+			)
+
+			got := card.Slots(Props{}).Get("header")
+			want := "header-large"
+			if got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+
+			if err := card.Compile(); err != nil {
+				t.Fatal(err)
+			}
+			if got := card.Slots(Props{}).Get("header"); got != want {
+				t.Errorf("compiled: got %s, want %s", got, want)
+			}
+		})
+
+		t.Run("inherit", func(t *testing.T) {
+			type ExtendedProps struct {
+				Props
+				Loading bool
+			}
+
+			extended := New(
+				Inherit(button, func(p ExtendedProps) Props { return p.Props }),
+				PredicateVariant(func(p ExtendedProps) bool { return p.Size == "" }, "no-size"),
+				PredicateVariant(func(p ExtendedProps) bool { return p.Loading }, "loading"),
+			)
+
+			overridden := New(
+				Inherit(button, func(p ExtendedProps) Props { return p.Props }),
+				DefaultVariant(func(p *ExtendedProps) *string { return &p.Size }, "small"),
+			)
+
+			tests := []struct {
+				name  string
+				cva   *Cva[ExtendedProps]
+				props ExtendedProps
+				want  string
+			}{
+				{
+					name:  "base-defaults",
+					cva:   extended,
+					props: ExtendedProps{Loading: true},
+					want:  "button button-medium medium-primary button-primary no-size loading",
+				},
+				{
+					name:  "extended-defaults",
+					cva:   overridden,
+					props: ExtendedProps{},
+					want:  "button button-small button-primary",
+				},
+			}
+
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					got := test.cva.Classes(test.props)
+					if got != test.want {
+						t.Errorf("got %s, want %s", got, test.want)
+					}
+				})
+			}
+		})
+	})
+
+	t.Run("Slots", func(t *testing.T) {
+		type Props struct {
+			Size     string
+			Closable bool
+		}
+
+		size := NewVariant(func(p Props) string { return p.Size })
+
+		dialog := New(
+			Base[Props]("dialog"),
+			InSlot("header",
+				Base[Props]("header"),
+				MapVariant(
+					func(p Props) string { return p.Size },
+					map[string]string{"small": "header-small", "large": "header-large"},
+				),
+			),
+			InSlots([]string{"header", "footer"},
+				Base[Props]("section"),
+				size.Is("large").Then("section-large"),
+			),
+			InSlot("close",
+				PredicateVariant(func(p Props) bool { return !p.Closable }, "hidden"),
+				CompoundVariant(
+					func(p Props) (string, bool) { return p.Size, p.Closable },
+					NewCompound("small", true, "close-small"),
+				),
+			),
+			MapVariant(
+				func(p Props) string { return p.Size },
+				map[string]string{"small": "dialog-small", "large": "dialog-large"},
+			),
+		)
+
+		tests := []struct {
+			name  string
+			props Props
+			want  SlotClasses
+		}{
+			{
+				name:  "small",
+				props: Props{Size: "small"},
+				want: SlotClasses{
+					"":       "dialog dialog-small",
+					"header": "header header-small section",
+					"footer": "section",
+					"close":  "hidden",
+				},
+			},
+			{
+				name:  "small-closable",
+				props: Props{Size: "small", Closable: true},
+				want: SlotClasses{
+					"":       "dialog dialog-small",
+					"header": "header header-small section",
+					"footer": "section",
+					"close":  "close-small",
+				},
+			},
+			{
+				name:  "large-closable",
+				props: Props{Size: "large", Closable: true},
+				want: SlotClasses{
+					"":       "dialog dialog-large",
+					"header": "header header-large section section-large",
+					"footer": "section section-large",
+					"close":  "",
+				},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := dialog.Slots(test.props)
+				if len(got) != len(test.want) {
+					t.Errorf("got %d slots, want %d", len(got), len(test.want))
+				}
+				for slot, want := range test.want {
+					if got.Get(slot) != want {
+						t.Errorf("slot %q: got %s, want %s", slot, got.Get(slot), want)
+					}
+				}
+				if classes := dialog.Classes(test.props); classes != test.want[""] {
+					t.Errorf("Classes: got %s, want %s", classes, test.want[""])
+				}
+			})
+		}
+
+		t.Run("undefined_slot", func(t *testing.T) {
+			got := dialog.Slots(Props{Size: "small"}).Get("body")
+			if got != "" {
+				t.Errorf("got %s, want empty string", got)
+			}
+		})
+
+		t.Run("nested", func(t *testing.T) {
+			card := New(
+				InSlot("header",
+					Base[Props]("header"),
+					InSlot("title", Base[Props]("title")),
+				),
+			)
+
+			got := card.Slots(Props{})
+			if got.Get("header") != "header" {
+				t.Errorf("header: got %s, want %s", got.Get("header"), "header")
+			}
+			if got.Get("title") != "title" {
+				t.Errorf("title: got %s, want %s", got.Get("title"), "title")
+			}
+		})
+
+		t.Run("inherit", func(t *testing.T) {
+			type ExtendedProps struct {
+				Props
+				Color string
+			}
+
+			extended := New(
+				Inherit(dialog, func(p ExtendedProps) Props { return p.Props }),
+				InSlot("header",
+					MapVariant(
+						func(p ExtendedProps) string { return p.Color },
+						map[string]string{"red": "header-red"},
+					),
+				),
+			)
+
+			got := extended.Slots(ExtendedProps{Props: Props{Size: "large"}, Color: "red"})
+			want := SlotClasses{
+				"":       "dialog dialog-large",
+				"header": "header header-large section section-large header-red",
+				"footer": "section section-large",
+				"close":  "hidden",
+			}
+			for slot, w := range want {
+				if got.Get(slot) != w {
+					t.Errorf("slot %q: got %s, want %s", slot, got.Get(slot), w)
+				}
+			}
+		})
+
+		t.Run("inherit_into_slot", func(t *testing.T) {
+			icon := New(
+				Base[Props]("icon"),
+				MapVariant(
+					func(p Props) string { return p.Size },
+					map[string]string{"small": "size-4", "large": "size-6"},
+				),
+			)
+
+			button := New(
+				Base[Props]("button"),
+				InSlot("icon", Inherit(icon, func(p Props) Props { return p })),
+			)
+
+			got := button.Slots(Props{Size: "large"})
+			if got.Get("") != "button" {
+				t.Errorf("root: got %s, want %s", got.Get(""), "button")
+			}
+			if got.Get("icon") != "icon size-6" {
+				t.Errorf("icon: got %s, want %s", got.Get("icon"), "icon size-6")
+			}
+		})
+	})
+
 	t.Run("composing_multiple", func(t *testing.T) {
 		type Props struct {
 			Size    string
@@ -857,3 +1224,106 @@ func TestCva(t *testing.T) {
 		}
 	})
 }
+
+func TestAppendClasses(t *testing.T) {
+	type Props struct {
+		Size     string
+		Style    string
+		Disabled bool
+		Custom   string
+	}
+
+	size := NewVariant(func(p Props) string { return p.Size }).WithDefault("medium")
+	base := New(Base[Props]("base\t"), InSlot("icon", Base[Props]("size-4")))
+	options := func() []Option[Props] {
+		return []Option[Props]{
+			Inherit(base, func(p Props) Props { return p }),
+			Base[Props]("  button ", "px-4"),
+			size.Map(map[string]string{"small": "h-8\npx-2", "medium": "h-10", "large": ""}),
+			CompoundVariant(
+				func(p Props) (string, string) { return size.get(p), p.Style },
+				NewCompoundOf(AnyValue[string](), OneOf("link"), "wild-1"),
+				NewCompound("small", "link", "exact"),
+				NewCompoundOf(OneOf("small"), AnyValue[string](), "wild-2"),
+				NewCompound("large", "link", "exact-large"),
+			),
+			PredicateVariant(func(p Props) bool { return p.Disabled }, "opacity-50 ", " cursor-not-allowed"),
+			Classes(func(p Props) string { return p.Custom }),
+			Classes(func(p Props) []string { return []string{p.Style, " tail "} }),
+		}
+	}
+
+	var props []Props
+	for _, size := range []string{"", "small", "large"} {
+		for _, style := range []string{"", "link"} {
+			for _, disabled := range []bool{false, true} {
+				for _, custom := range []string{"", " a\tb "} {
+					props = append(props, Props{size, style, disabled, custom})
+				}
+			}
+		}
+	}
+
+	tests := []struct {
+		name    string
+		c       *Cva[Props]
+		compile bool
+	}{
+		{name: "dynamic", c: New(options()...)},
+		{name: "merged", c: New(append(options(), WithMerger[Props](Dedupe))...)},
+		{name: "cached", c: New(append(options(), CacheFunc(0, func(p Props) Props { return p }))...)},
+		{name: "compiled", c: New(options()...), compile: true},
+		{
+			name:    "compiled_merged",
+			c:       New(append(options(), WithMerger[Props](Dedupe))...),
+			compile: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.compile {
+				if err := test.c.Compile(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, p := range props {
+				slots := test.c.Slots(p)
+				if got, want := string(test.c.AppendClasses([]byte("x "), p)), "x "+slots[""]; got != want {
+					t.Errorf("%+v: got %s, want %s", p, got, want)
+				}
+				got, want := string(test.c.AppendSlotClasses(nil, p, "icon")), slots["icon"]
+				if got != want {
+					t.Errorf("%+v slot icon: got %s, want %s", p, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("order", func(t *testing.T) {
+		c := New(options()...)
+		got := c.Classes(Props{Size: "small", Style: "link", Disabled: true, Custom: " a\tb "})
+		want := "base button px-4 h-8 px-2 wild-1 exact wild-2 opacity-50 cursor-not-allowed a b link tail"
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("allocations", func(t *testing.T) {
+		c := New(options()[:5]...)
+		p := Props{Size: "small", Style: "link", Disabled: true}
+		buf := make([]byte, 0, 256)
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = c.AppendClasses(buf[:0], p)
+		})
+		if allocs != 0 {
+			t.Errorf("got %v allocations, want 0", allocs)
+		}
+		allocs = testing.AllocsPerRun(100, func() {
+			c.Classes(p)
+		})
+		if allocs != 1 {
+			t.Errorf("got %v allocations for Classes, want 1", allocs)
+		}
+	})
+}
@@ -0,0 +1,480 @@
+// Package cvajs exports cva components as JavaScript or TypeScript modules defining the same
+// component with the upstream class-variance-authority or tailwind-variants libraries, so that a
+// single Go definition can serve both Go templates and JavaScript front ends.
+//
+// Generation is intended to be run with go generate, from a small program importing the package
+// defining the component:
+//
+//	//go:build ignore
+//
+//	package main
+//
+//	func main() {
+//		err := cvajs.WriteFile(buttons.Button, cvajs.Config{Name: "button"}, "button.ts")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// Base, map and compound options are exported as is, along with the default values of their
+// variants. Predicate options are exported as compound variants when their condition is a
+// conjunction of Variant.Is and Variant.In matchers, or of negated ones on variants with both
+// declared values and a default. Options that cannot be represented, such as those created with
+// Classes, PredicateVariant, Variant.Test, Inherit or InSlot, make generation fail. So do options
+// depending on unnamed variants, which must be named with Variant.WithName, Label or NameVariants.
+//
+// The upstream libraries apply all variants before compound variants, so the order of the exported
+// classes can differ from the Go component's when predicate or compound options are interleaved
+// with map options.
+//
+// Only defaults set with Variant.WithDefault are exported. The DefaultVariant and DefaultVariants
+// options set defaults with opaque functions, and make generation fail.
+package cvajs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Roundaround/cva-go"
+)
+
+// Library identifies the JavaScript library the exported module uses.
+type Library int
+
+const (
+	// CVA is the class-variance-authority library.
+	CVA Library = iota
+	// TailwindVariants is the tailwind-variants library.
+	TailwindVariants
+)
+
+// Config configures the exported module.
+type Config struct {
+	// Library is the library used by the exported module.
+	Library Library
+	// Name is the name of the exported component, e.g. "button".
+	Name string
+	// TypeName is the name of the exported VariantProps type. It defaults to the component's name
+	// followed by "Variants", e.g. "ButtonVariants".
+	TypeName string
+	// JavaScript exports a plain JavaScript module, without the VariantProps type.
+	JavaScript bool
+}
+
+// variant is a single exported variant.
+type variant struct {
+	name    string
+	keys    []string
+	classes map[string]string
+	// value maps the variant's keys to their JavaScript literals, used in compound variants and
+	// defaults.
+	value map[string]string
+	def   string
+}
+
+// compound is a single exported compound variant.
+type compound struct {
+	names   []string
+	values  map[string][]string
+	classes string
+}
+
+// exporter holds the component's definition as understood by the upstream libraries.
+type exporter struct {
+	base      []string
+	variants  []*variant
+	compounds []compound
+}
+
+// Generate returns the source code of a module exporting the component, as configured.
+func Generate[P any](c *cva.Cva[P], config Config) ([]byte, error) {
+	if !isBinding(config.Name) {
+		return nil, fmt.Errorf("cvajs: Name %q is not a valid identifier", config.Name)
+	}
+	if config.TypeName == "" {
+		runes := []rune(config.Name)
+		runes[0] = unicode.ToUpper(runes[0])
+		config.TypeName = string(runes) + "Variants"
+	}
+	if !isBinding(config.TypeName) {
+		return nil, fmt.Errorf("cvajs: TypeName %q is not a valid identifier", config.TypeName)
+	}
+
+	e := &exporter{}
+	if err := e.collect(c.Schema()); err != nil {
+		return nil, err
+	}
+	return e.module(config), nil
+}
+
+// WriteFile writes the generated module to the given path.
+func WriteFile[P any](c *cva.Cva[P], config Config, path string) error {
+	code, err := Generate(c, config)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, code, 0o644)
+}
+
+// collect gathers the base classes, variants and compound variants of the schema, failing for
+// options that cannot be represented.
+func (e *exporter) collect(s cva.Schema) error {
+	if len(s.Defaults) > 0 {
+		d := s.Defaults[0]
+		return fmt.Errorf("cvajs: default variants option%s cannot be exported, set defaults with "+
+			"Variant.WithDefault instead", location(d.File, d.Line))
+	}
+	for i, opt := range s.Options {
+		if err := e.option(opt); err != nil {
+			return fmt.Errorf("cvajs: %s option %d%s: %w", opt.Kind, i, location(opt.File, opt.Line),
+				err)
+		}
+	}
+	return nil
+}
+
+// location formats the location of an option for error messages, if known.
+func location(file string, line int) string {
+	if file == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s:%d)", file, line)
+}
+
+// option adds a single option to the exported definition.
+func (e *exporter) option(opt cva.OptionSchema) error {
+	if len(opt.Slots) > 0 {
+		return errors.New("options targeting slots cannot be exported")
+	}
+
+	switch opt.Kind {
+	case cva.KindBase:
+		e.base = append(e.base, opt.Classes...)
+		return nil
+
+	case cva.KindMap:
+		v, err := e.variant(opt.Variants[0])
+		if err != nil {
+			return err
+		}
+		for _, cs := range opt.Cases {
+			key, err := v.add(cs.Values[0][0])
+			if err != nil {
+				return err
+			}
+			v.classes[key] = cva.JoinClasses(v.classes[key], cva.JoinClasses(cs.Classes...))
+		}
+		return nil
+
+	case cva.KindCompound:
+		vars := make([]*variant, len(opt.Variants))
+		for i, vs := range opt.Variants {
+			v, err := e.variant(vs)
+			if err != nil {
+				return err
+			}
+			vars[i] = v
+		}
+		for _, cs := range opt.Cases {
+			cmp := compound{values: make(map[string][]string), classes: cva.JoinClasses(cs.Classes...)}
+			for i, vals := range cs.Values {
+				if vals == nil {
+					continue
+				}
+				if err := cmp.match(vars[i], vals); err != nil {
+					return err
+				}
+			}
+			e.compounds = append(e.compounds, cmp)
+		}
+		return nil
+
+	case cva.KindPredicate:
+		cmp := compound{values: make(map[string][]string), classes: cva.JoinClasses(opt.Classes...)}
+		if err := e.condition(&cmp, *opt.Condition); err != nil {
+			return err
+		}
+		e.compounds = append(e.compounds, cmp)
+		return nil
+	}
+	return fmt.Errorf("%s options cannot be exported", opt.Kind)
+}
+
+// condition adds the constraints of a predicate option's condition to the compound variant.
+func (e *exporter) condition(cmp *compound, cond cva.Condition) error {
+	switch cond.Op {
+	case cva.OpIs, cva.OpIn:
+		v, err := e.variant(cond.Variant)
+		if err != nil {
+			return err
+		}
+		return cmp.match(v, cond.Values)
+
+	case cva.OpAnd:
+		for _, operand := range cond.Operands {
+			if err := e.condition(cmp, operand); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case cva.OpNot:
+		operand := cond.Operands[0]
+		vs := operand.Variant
+		if (operand.Op == cva.OpIs || operand.Op == cva.OpIn) && len(vs.Values) > 0 && vs.HasDefault {
+			var vals []any
+			for _, val := range vs.Values {
+				if !slices.Contains(operand.Values, val) {
+					vals = append(vals, val)
+				}
+			}
+			v, err := e.variant(vs)
+			if err != nil {
+				return err
+			}
+			return cmp.match(v, vals)
+		}
+	}
+	return fmt.Errorf("condition %s cannot be exported", cond)
+}
+
+// variant returns the exported variant for the given variant schema, adding it if needed along
+// with its known values and default.
+func (e *exporter) variant(vs cva.VariantSchema) (*variant, error) {
+	if vs.Name == "" {
+		return nil, errors.New("unnamed variants cannot be exported, name them with " +
+			"Variant.WithName, Label or NameVariants")
+	}
+
+	i := slices.IndexFunc(e.variants, func(v *variant) bool { return v.name == vs.Name })
+	if i < 0 {
+		i = len(e.variants)
+		e.variants = append(e.variants, &variant{
+			name:    vs.Name,
+			classes: make(map[string]string),
+			value:   make(map[string]string),
+		})
+	}
+	v := e.variants[i]
+
+	for _, val := range vs.Values {
+		if val == nil {
+			continue
+		}
+		if _, err := v.add(val); err != nil {
+			return nil, err
+		}
+	}
+	if vs.HasDefault && vs.Default != nil {
+		key, err := v.add(vs.Default)
+		if err != nil {
+			return nil, err
+		}
+		if v.def != "" && v.def != key {
+			return nil, fmt.Errorf("variant %q has conflicting defaults %q and %q", v.name, v.def, key)
+		}
+		v.def = key
+	}
+	return v, nil
+}
+
+// add adds a value to the variant's keys if needed, returning its key.
+func (v *variant) add(val any) (string, error) {
+	key, literal, err := encodeValue(val)
+	if err != nil {
+		return "", fmt.Errorf("variant %q: %w", v.name, err)
+	}
+	if _, ok := v.value[key]; !ok {
+		v.keys = append(v.keys, key)
+		v.value[key] = literal
+	}
+	return key, nil
+}
+
+// match constrains the compound variant to the given values of the variant, intersecting them
+// with any earlier constraint on the same variant.
+func (cmp *compound) match(v *variant, vals []any) error {
+	var keys []string
+	for _, val := range vals {
+		key, err := v.add(val)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key)
+	}
+
+	if prev, ok := cmp.values[v.name]; ok {
+		keys = slices.DeleteFunc(keys, func(k string) bool { return !slices.Contains(prev, k) })
+	} else {
+		cmp.names = append(cmp.names, v.name)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("condition on variant %q never matches", v.name)
+	}
+	cmp.values[v.name] = keys
+	return nil
+}
+
+// encodeValue returns the object key and JavaScript literal of a variant value.
+func encodeValue(val any) (string, string, error) {
+	if val == nil {
+		return "", "", errors.New("zero values cannot be exported")
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Bool:
+		key := strconv.FormatBool(rv.Bool())
+		return key, key, nil
+	case reflect.String:
+		return rv.String(), quote(rv.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		key := strconv.FormatInt(rv.Int(), 10)
+		return key, key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		key := strconv.FormatUint(rv.Uint(), 10)
+		return key, key, nil
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); !math.IsInf(f, 0) && !math.IsNaN(f) {
+			key := strconv.FormatFloat(f, 'g', -1, 64)
+			return key, key, nil
+		}
+	}
+	return "", "", fmt.Errorf("value %#v cannot be exported", val)
+}
+
+// module returns the source code of the exported module.
+func (e *exporter) module(config Config) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cva-go cvajs. DO NOT EDIT.\n\n")
+
+	fn, pkg := "cva", "class-variance-authority"
+	if config.Library == TailwindVariants {
+		fn, pkg = "tv", "tailwind-variants"
+	}
+	if config.JavaScript {
+		fmt.Fprintf(&b, "import { %s } from %q;\n\n", fn, pkg)
+	} else {
+		fmt.Fprintf(&b, "import { %s, type VariantProps } from %q;\n\n", fn, pkg)
+	}
+
+	base := quote(cva.JoinClasses(e.base...))
+	if config.Library == TailwindVariants {
+		fmt.Fprintf(&b, "export const %s = tv({\n", config.Name)
+		if len(e.base) > 0 {
+			fmt.Fprintf(&b, "  base: %s,\n", base)
+		}
+	} else {
+		fmt.Fprintf(&b, "export const %s = cva(%s, {\n", config.Name, base)
+	}
+
+	if len(e.variants) > 0 {
+		b.WriteString("  variants: {\n")
+		for _, v := range e.variants {
+			fmt.Fprintf(&b, "    %s: {\n", key(v.name))
+			for _, k := range v.keys {
+				fmt.Fprintf(&b, "      %s: %s,\n", key(k), quote(v.classes[k]))
+			}
+			b.WriteString("    },\n")
+		}
+		b.WriteString("  },\n")
+	}
+
+	if len(e.compounds) > 0 {
+		b.WriteString("  compoundVariants: [\n")
+		for _, cmp := range e.compounds {
+			b.WriteString("    {\n")
+			for _, name := range cmp.names {
+				v := e.variants[slices.IndexFunc(e.variants, func(v *variant) bool {
+					return v.name == name
+				})]
+				literals := make([]string, len(cmp.values[name]))
+				for i, k := range cmp.values[name] {
+					literals[i] = v.value[k]
+				}
+				value := literals[0]
+				if len(literals) > 1 {
+					value = "[" + strings.Join(literals, ", ") + "]"
+				}
+				fmt.Fprintf(&b, "      %s: %s,\n", key(name), value)
+			}
+			fmt.Fprintf(&b, "      class: %s,\n", quote(cmp.classes))
+			b.WriteString("    },\n")
+		}
+		b.WriteString("  ],\n")
+	}
+
+	if slices.ContainsFunc(e.variants, func(v *variant) bool { return v.def != "" }) {
+		b.WriteString("  defaultVariants: {\n")
+		for _, v := range e.variants {
+			if v.def != "" {
+				fmt.Fprintf(&b, "    %s: %s,\n", key(v.name), v.value[v.def])
+			}
+		}
+		b.WriteString("  },\n")
+	}
+
+	b.WriteString("});\n")
+	if !config.JavaScript {
+		fmt.Fprintf(&b, "\nexport type %s = VariantProps<typeof %s>;\n", config.TypeName, config.Name)
+	}
+	return b.Bytes()
+}
+
+// quote returns s as a JavaScript string literal.
+func quote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// key returns s as a JavaScript object key, quoted unless it is a valid identifier.
+func key(s string) string {
+	if isIdentifier(s) {
+		return s
+	}
+	return quote(s)
+}
+
+// isIdentifier reports whether s is a valid JavaScript identifier made of ASCII characters.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r == '_' || r == '$' || r <= unicode.MaxASCII && unicode.IsLetter(r) ||
+			i > 0 && r <= unicode.MaxASCII && unicode.IsDigit(r) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// reserved lists the reserved words of JavaScript and TypeScript that cannot name a binding.
+var reserved = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true,
+	"function": true, "if": true, "implements": true, "import": true, "in": true,
+	"instanceof": true, "interface": true, "let": true, "new": true, "null": true,
+	"package": true, "private": true, "protected": true, "public": true, "return": true,
+	"static": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+	"yield": true, "await": true,
+}
+
+// isBinding reports whether s can name the exported constant or type.
+func isBinding(s string) bool {
+	return isIdentifier(s) && !reserved[s]
+}
@@ -0,0 +1,200 @@
+package cvajs
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Roundaround/cva-go"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected modules in testdata")
+
+type Size string
+
+const (
+	Small  Size = "small"
+	Medium Size = "medium"
+	Large  Size = "large"
+)
+
+type Props struct {
+	Size     Size
+	Style    string
+	Disabled bool
+	Level    int
+}
+
+var (
+	size = cva.NewVariant(func(p Props) Size { return p.Size }).
+		WithName("size").
+		WithValues(Small, Medium, Large).
+		WithDefault(Medium)
+	style    = cva.NewVariant(func(p Props) string { return p.Style }).WithName("style")
+	disabled = cva.NewVariant(func(p Props) bool { return p.Disabled }).WithName("disabled")
+)
+
+var button = cva.New(
+	cva.Base[Props]("inline-flex items-center", "justify-center"),
+	size.Map(map[Size]string{
+		Small:  "h-9 px-3",
+		Medium: "h-10 px-4 py-2",
+		Large:  "h-11 px-8",
+	}),
+	cva.Label("level", cva.MapVariant(func(p Props) int { return p.Level }, map[int]string{
+		1: "text-sm",
+		2: "text-lg",
+	})),
+	disabled.Is(true).Then("opacity-50 \"quoted\""),
+	cva.NameVariants([]string{"size", "style"}, cva.CompoundVariant(
+		func(p Props) (Size, string) { return p.Size, p.Style },
+		cva.NewCompoundOf(cva.OneOf(Small, Medium), cva.AnyValue[string](), "rounded-md"),
+		cva.NewCompound(Large, "link", "underline"),
+	)),
+	cva.All(size.IsNot(Small), style.In("primary", "link")).Then("font-semibold"),
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		path   string
+	}{
+		{name: "cva", config: Config{Name: "button"}, path: "testdata/button.ts"},
+		{
+			name:   "tailwind-variants",
+			config: Config{Library: TailwindVariants, Name: "button", TypeName: "ButtonProps"},
+			path:   "testdata/button.tv.ts",
+		},
+		{
+			name:   "javascript",
+			config: Config{Name: "button", JavaScript: true},
+			path:   "testdata/button.js",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if *update {
+				if err := WriteFile(button, test.config, test.path); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := Generate(button, test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := os.ReadFile(test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("generated module differs from %s, run go test with -update", test.path)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	level := cva.NewVariant(func(p Props) int { return p.Level })
+
+	tests := []struct {
+		name   string
+		opts   []cva.Option[Props]
+		config Config
+		want   string
+	}{
+		{
+			name:   "invalid-name",
+			config: Config{Name: "my-button"},
+			want:   `cvajs: Name "my-button" is not a valid identifier`,
+		},
+		{
+			name:   "reserved-name",
+			config: Config{Name: "class"},
+			want:   `cvajs: Name "class" is not a valid identifier`,
+		},
+		{
+			name:   "invalid-type-name",
+			config: Config{Name: "button", TypeName: "Button Props"},
+			want:   `cvajs: TypeName "Button Props" is not a valid identifier`,
+		},
+		{
+			name: "predicate",
+			opts: []cva.Option[Props]{
+				cva.PredicateVariant(func(p Props) bool { return p.Level > 1 }, "a"),
+			},
+			want: "predicate option 0",
+		},
+		{
+			name: "test",
+			opts: []cva.Option[Props]{
+				size.Test(func(s Size) bool { return s != Small }).Then("a"),
+			},
+			want: "condition test(size) cannot be exported",
+		},
+		{
+			name: "or",
+			opts: []cva.Option[Props]{size.Is(Small).Or(style.Is("link")).Then("a")},
+			want: `condition size == "small" || style == "link" cannot be exported`,
+		},
+		{
+			name: "not-without-default",
+			opts: []cva.Option[Props]{style.IsNot("link").Then("a")},
+			want: `condition !(style == "link") cannot be exported`,
+		},
+		{
+			name: "unnamed",
+			opts: []cva.Option[Props]{level.Map(map[int]string{1: "a"})},
+			want: "unnamed variants cannot be exported",
+		},
+		{
+			name: "unnamed-compound",
+			opts: []cva.Option[Props]{cva.CompoundVariant(
+				func(p Props) (Size, string) { return p.Size, p.Style },
+				cva.NewCompound(Large, "link", "underline"),
+			)},
+			want: "compound option 0",
+		},
+		{
+			name: "classes",
+			opts: []cva.Option[Props]{cva.Classes(func(p Props) string { return "a" })},
+			want: "classes option 0",
+		},
+		{
+			name: "slot",
+			opts: []cva.Option[Props]{cva.InSlot("icon", cva.Base[Props]("a"))},
+			want: "options targeting slots cannot be exported",
+		},
+		{
+			name: "default-variant",
+			opts: []cva.Option[Props]{
+				cva.DefaultVariant(func(p *Props) *Size { return &p.Size }, Medium),
+				size.Map(map[Size]string{Small: "h-8", Medium: "h-10"}),
+			},
+			want: "default variants option (",
+		},
+		{
+			name: "conflicting-defaults",
+			opts: []cva.Option[Props]{
+				size.Map(map[Size]string{Small: "a"}),
+				cva.NewVariant(func(p Props) Size { return p.Size }).WithName("size").
+					WithDefault(Large).Is(Small).Then("b"),
+			},
+			want: `variant "size" has conflicting defaults "medium" and "large"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.config.Name == "" {
+				test.config.Name = "button"
+			}
+			_, err := Generate(cva.New(test.opts...), test.config)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got %v, want error containing %s", err, test.want)
+			}
+		})
+	}
+}
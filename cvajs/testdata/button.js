@@ -0,0 +1,47 @@
+// Code generated by cva-go cvajs. DO NOT EDIT.
+
+import { cva } from "class-variance-authority";
+
+export const button = cva("inline-flex items-center justify-center", {
+  variants: {
+    size: {
+      small: "h-9 px-3",
+      medium: "h-10 px-4 py-2",
+      large: "h-11 px-8",
+    },
+    level: {
+      "1": "text-sm",
+      "2": "text-lg",
+    },
+    disabled: {
+      true: "",
+    },
+    style: {
+      link: "",
+      primary: "",
+    },
+  },
+  compoundVariants: [
+    {
+      disabled: true,
+      class: "opacity-50 \"quoted\"",
+    },
+    {
+      size: ["small", "medium"],
+      class: "rounded-md",
+    },
+    {
+      size: "large",
+      style: "link",
+      class: "underline",
+    },
+    {
+      size: ["medium", "large"],
+      style: ["primary", "link"],
+      class: "font-semibold",
+    },
+  ],
+  defaultVariants: {
+    size: "medium",
+  },
+});
@@ -0,0 +1,436 @@
+// Package cvalint defines an analyzer flagging common mistakes in component definitions built with
+// github.com/Roundaround/cva-go.
+//
+// The analyzer only inspects constant arguments, i.e. string literals and named constants, and
+// reports:
+//
+//   - classes applied by both Base (or Static) and a variant option of the same component
+//   - NewCompound entries with the same values as an earlier entry of the same CompoundVariant
+//   - Variant.WithName, Variant.WithDefault and Variant.WithValues calls made after a component
+//     using the variant was created, which panic since the component froze its configuration
+//   - Variant.Map keys outside of the variant's Variant.WithValues list, which are never applied
+//   - empty class strings, except for map values, which document values without classes
+//   - classes with unbalanced brackets or parentheses, e.g. "w-[10px"
+//
+// It can be run with go vet through the cvavet command:
+//
+//	go install github.com/Roundaround/cva-go/cmd/cvavet@latest
+//	go vet -vettool=$(which cvavet) ./...
+package cvalint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/Roundaround/cva-go/internal/cvaast"
+)
+
+// Analyzer reports common mistakes in cva component definitions. See the package documentation
+// for the list of checks.
+var Analyzer = &analysis.Analyzer{
+	Name:     "cvalint",
+	Doc:      "report common mistakes in cva component definitions",
+	URL:      "https://pkg.go.dev/github.com/Roundaround/cva-go/cvalint",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// optionArgs maps each cva function taking options to the index of the first option argument.
+var optionArgs = map[string]int{
+	"New":        0,
+	"NewE":       0,
+	"MustNew":    0,
+	"Label":      1,
+	"Exhaustive": 1,
+	"InSlot":     1,
+	"InSlots":    1,
+}
+
+// exactCompounds lists the constructors of compound entries matching exact values.
+var exactCompounds = []string{"NewCompound", "NewCompound3", "NewCompound4"}
+
+// derivations lists the Variant methods deriving options or matchers from a variant, mapped to
+// whether their arguments (or map keys) are values of the variant.
+var derivations = map[string]bool{
+	"Variant.Map":   true,
+	"Variant.Is":    true,
+	"Variant.In":    true,
+	"Variant.IsNot": true,
+	"Variant.NotIn": true,
+	"Variant.Test":  false,
+}
+
+// class is a single class token found in a constant argument.
+type class struct {
+	pos   token.Pos
+	token string
+}
+
+// variantUse records how a variable holding a Variant is configured and used.
+type variantUse struct {
+	// values holds the exact representation of the constant values set with WithValues, if known.
+	values []string
+	// created is the position of the first New, NewE or MustNew call directly deriving options
+	// from the variant.
+	created token.Pos
+	// configs lists the WithName, WithDefault and WithValues calls made on the variable.
+	configs []*ast.CallExpr
+	// uses lists the calls whose values must be within the variant's values.
+	uses []*ast.CallExpr
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	variants := make(map[types.Object]*variantUse)
+	variant := func(obj types.Object) *variantUse {
+		if variants[obj] == nil {
+			variants[obj] = &variantUse{}
+		}
+		return variants[obj]
+	}
+
+	nodes := []ast.Node{(*ast.CallExpr)(nil), (*ast.ValueSpec)(nil), (*ast.AssignStmt)(nil)}
+	insp.Preorder(nodes, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if i < len(n.Values) {
+					recordChain(pass, variant, pass.TypesInfo.Defs[name], n.Values[i])
+				}
+			}
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return
+			}
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					obj := pass.TypesInfo.Defs[id]
+					if obj == nil {
+						obj = pass.TypesInfo.Uses[id]
+					}
+					recordChain(pass, variant, obj, n.Rhs[i])
+				}
+			}
+		case *ast.CallExpr:
+			checkCall(pass, variant, n)
+		}
+	})
+
+	for _, v := range variants {
+		for _, call := range v.configs {
+			if v.created.IsValid() && call.Pos() > v.created {
+				pass.Reportf(call.Pos(),
+					"%s is called after the component at %s using the variant was created, "+
+						"and panics since the component froze the variant's configuration",
+					cvaast.CalleeName(pass.TypesInfo, call.Fun), pass.Fset.Position(v.created))
+			}
+		}
+		if v.values != nil {
+			for _, call := range v.uses {
+				checkValues(pass, call, v.values)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// checkCall checks a single call, recording how the variants it refers to are used.
+func checkCall(pass *analysis.Pass, variant func(types.Object) *variantUse, call *ast.CallExpr) {
+	name := cvaast.CalleeName(pass.TypesInfo, call.Fun)
+	if name == "" {
+		return
+	}
+
+	if obj := receiverObject(pass.TypesInfo, call.Fun); obj != nil {
+		v := variant(obj)
+		if name == "Variant.WithValues" || name == "Variant.WithDefault" ||
+			name == "Variant.WithName" {
+			v.configs = append(v.configs, call)
+		}
+		if name == "Variant.WithValues" {
+			if vals, ok := constantValues(pass.TypesInfo, call.Args); ok {
+				v.values = vals
+			}
+		}
+		if derivations[name] {
+			v.uses = append(v.uses, call)
+		}
+	}
+
+	switch name {
+	case "New", "NewE", "MustNew":
+		ast.Inspect(call, func(n ast.Node) bool {
+			if inner, ok := n.(*ast.CallExpr); ok {
+				if _, ok := derivations[cvaast.CalleeName(pass.TypesInfo, inner.Fun)]; ok {
+					if obj := receiverObject(pass.TypesInfo, inner.Fun); obj != nil {
+						if v := variant(obj); !v.created.IsValid() || call.Pos() < v.created {
+							v.created = call.Pos()
+						}
+					}
+				}
+			}
+			return true
+		})
+		checkBaseDuplicates(pass, call.Args)
+	case "CompoundVariant", "CompoundVariant3", "CompoundVariant4":
+		checkCompoundDuplicates(pass, call.Args[1:])
+	}
+
+	for _, c := range callClasses(pass.TypesInfo, call, true) {
+		if c.token == "" {
+			pass.Reportf(c.pos, "empty class string")
+		} else if !balanced(c.token) {
+			pass.Reportf(c.pos, "unbalanced brackets in class %q", c.token)
+		}
+	}
+}
+
+// recordChain records the WithValues and WithDefault calls chained onto the NewVariant call
+// assigned to obj, e.g. cva.NewVariant(getter).WithValues("small", "large").
+func recordChain(
+	pass *analysis.Pass,
+	variant func(types.Object) *variantUse,
+	obj types.Object,
+	expr ast.Expr,
+) {
+	if obj == nil {
+		return
+	}
+	for {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		switch cvaast.CalleeName(pass.TypesInfo, call.Fun) {
+		case "Variant.WithValues":
+			if vals, ok := constantValues(pass.TypesInfo, call.Args); ok {
+				variant(obj).values = vals
+			}
+		case "Variant.WithDefault", "Variant.WithName":
+		default:
+			return
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		expr = sel.X
+	}
+}
+
+// checkValues reports the map keys or matched values of the call which are not one of the
+// variant's values.
+func checkValues(pass *analysis.Pass, call *ast.CallExpr, values []string) {
+	var exprs []ast.Expr
+	if cvaast.CalleeName(pass.TypesInfo, call.Fun) == "Variant.Map" {
+		if len(call.Args) == 0 {
+			return
+		}
+		lit, ok := ast.Unparen(call.Args[0]).(*ast.CompositeLit)
+		if !ok {
+			return
+		}
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				exprs = append(exprs, kv.Key)
+			}
+		}
+	} else {
+		exprs = call.Args
+	}
+
+	for _, expr := range exprs {
+		tv, ok := pass.TypesInfo.Types[expr]
+		if !ok || tv.Value == nil || isZero(tv.Value) {
+			continue
+		}
+		if !slices.Contains(values, tv.Value.ExactString()) {
+			pass.Reportf(expr.Pos(),
+				"%s is not one of the variant's values set with WithValues, and is never used",
+				tv.Value.ExactString())
+		}
+	}
+}
+
+// isZero reports whether the constant is the zero value of its type. Values outside of a variant's
+// values are read as the zero value, which is thus always used.
+func isZero(val constant.Value) bool {
+	switch val.Kind() {
+	case constant.String:
+		return constant.StringVal(val) == ""
+	case constant.Bool:
+		return !constant.BoolVal(val)
+	case constant.Int, constant.Float, constant.Complex:
+		return constant.Sign(val) == 0
+	}
+	return false
+}
+
+// checkBaseDuplicates reports the classes of variant options which are also applied by the Base
+// or Static options of the same component and slot.
+func checkBaseDuplicates(pass *analysis.Pass, opts []ast.Expr) {
+	var base, variants []class
+	var collect func(opts []ast.Expr)
+	collect = func(opts []ast.Expr) {
+		for _, opt := range opts {
+			call, ok := ast.Unparen(opt).(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			switch name := cvaast.CalleeName(pass.TypesInfo, call.Fun); name {
+			case "Base", "Static":
+				base = append(base, callClasses(pass.TypesInfo, call, false)...)
+			case "Label", "Exhaustive":
+				collect(call.Args[optionArgs[name]:])
+			case "CompoundVariant", "CompoundVariant3", "CompoundVariant4":
+				for _, arg := range call.Args[1:] {
+					if entry, ok := ast.Unparen(arg).(*ast.CallExpr); ok {
+						variants = append(variants, callClasses(pass.TypesInfo, entry, false)...)
+					}
+				}
+			default:
+				// Options targeting other slots, like InSlot, are checked separately.
+				variants = append(variants, callClasses(pass.TypesInfo, call, false)...)
+			}
+		}
+	}
+	collect(opts)
+
+	for _, c := range variants {
+		if slices.ContainsFunc(base, func(b class) bool { return b.token == c.token }) {
+			pass.Reportf(c.pos, "class %q is already applied by Base", c.token)
+		}
+	}
+}
+
+// checkCompoundDuplicates reports NewCompound entries with the same values as an earlier entry,
+// which they replace.
+func checkCompoundDuplicates(pass *analysis.Pass, compounds []ast.Expr) {
+	seen := make(map[string]token.Pos)
+	for _, expr := range compounds {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		name := cvaast.CalleeName(pass.TypesInfo, call.Fun)
+		if !slices.Contains(exactCompounds, name) {
+			continue
+		}
+		vals, ok := constantValues(pass.TypesInfo, call.Args[:cvaast.ClassArgs[name]])
+		if !ok {
+			continue
+		}
+		key := strings.Join(vals, ", ")
+		if prev, ok := seen[key]; ok {
+			pass.Reportf(call.Pos(), "duplicate compound entry (%s) replaces the entry at %s",
+				key, pass.Fset.Position(prev))
+		}
+		seen[key] = call.Pos()
+	}
+}
+
+// balanced reports whether the brackets and parentheses of the class are balanced.
+func balanced(class string) bool {
+	var stack []rune
+	for _, r := range class {
+		switch r {
+		case '[', '(':
+			stack = append(stack, r)
+		case ']', ')':
+			open := '['
+			if r == ')' {
+				open = '('
+			}
+			if len(stack) == 0 || stack[len(stack)-1] != open {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return len(stack) == 0
+}
+
+// callClasses returns the classes of the constant class list arguments of the call. If
+// reportEmpty is true, empty class lists yield a single empty class, except for map values, which
+// document values without classes.
+func callClasses(info *types.Info, call *ast.CallExpr, reportEmpty bool) []class {
+	first, ok := cvaast.ClassArgs[cvaast.CalleeName(info, call.Fun)]
+	if !ok {
+		return nil
+	}
+
+	var classes []class
+	add := func(expr ast.Expr, isMapValue bool) {
+		tv, ok := info.Types[expr]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+		fields := strings.Fields(constant.StringVal(tv.Value))
+		if len(fields) == 0 && reportEmpty && !isMapValue {
+			classes = append(classes, class{pos: expr.Pos()})
+		}
+		for _, f := range fields {
+			classes = append(classes, class{pos: expr.Pos(), token: f})
+		}
+	}
+
+	// Composite literals are scanned recursively, for slices of class lists and maps of them.
+	var visit func(expr ast.Expr, isMapValue bool)
+	visit = func(expr ast.Expr, isMapValue bool) {
+		lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+		if !ok {
+			add(expr, isMapValue)
+			return
+		}
+		for _, elt := range lit.Elts {
+			kv, isMap := elt.(*ast.KeyValueExpr)
+			if isMap {
+				elt = kv.Value
+			}
+			visit(elt, isMap)
+		}
+	}
+	for _, arg := range call.Args[first:] {
+		visit(arg, false)
+	}
+	return classes
+}
+
+// constantValues returns the exact representation of each of the constant expressions, or false
+// if any of them is not a constant.
+func constantValues(info *types.Info, exprs []ast.Expr) ([]string, bool) {
+	vals := make([]string, len(exprs))
+	for i, expr := range exprs {
+		tv, ok := info.Types[expr]
+		if !ok || tv.Value == nil {
+			return nil, false
+		}
+		vals[i] = tv.Value.ExactString()
+	}
+	return vals, true
+}
+
+// receiverObject returns the variable the method called by fun is called on, or nil if the
+// receiver is not a variable.
+func receiverObject(info *types.Info, fun ast.Expr) types.Object {
+	sel, ok := ast.Unparen(fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	id, ok := ast.Unparen(sel.X).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	if v, ok := info.Uses[id].(*types.Var); ok {
+		return v
+	}
+	return nil
+}
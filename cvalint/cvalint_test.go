@@ -0,0 +1,34 @@
+package cvalint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestBalanced(t *testing.T) {
+	tests := []struct {
+		class string
+		want  bool
+	}{
+		{class: "px-4", want: true},
+		{class: "w-[10px]", want: true},
+		{class: "grid-cols-[repeat(2,minmax(0,1fr))]", want: true},
+		{class: "[&_svg]:size-4", want: true},
+		{class: "w-[10px", want: false},
+		{class: "w-10px]", want: false},
+		{class: "bg-[url(a.png])", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.class, func(t *testing.T) {
+			if got := balanced(test.class); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
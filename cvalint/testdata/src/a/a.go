@@ -0,0 +1,79 @@
+package a
+
+import "github.com/Roundaround/cva-go"
+
+type Props struct {
+	Size  string
+	Style string
+	Count int
+}
+
+const (
+	small = "small"
+	base  = "inline-flex px-4"
+)
+
+var size = cva.NewVariant(func(p Props) string { return p.Size }).
+	WithName("size").
+	WithValues(small, "medium", "large")
+
+var style = cva.NewVariant(func(p Props) string { return p.Style })
+
+var Button = cva.New(
+	cva.Base[Props](base, "items-center"),
+	size.Map(map[string]string{
+		small:   "h-8 px-2",
+		"large": "h-12 inline-flex", // want `class "inline-flex" is already applied by Base`
+		"huge":  "h-16",             // want `"huge" is not one of the variant's values set with WithValues, and is never used`
+		"":      "",
+	}),
+	cva.Label("style", cva.MapVariant(
+		func(p Props) string { return p.Style },
+		map[string][]string{"link": {"underline", "px-4"}}, // want `class "px-4" is already applied by Base`
+	)),
+	cva.CompoundVariant(
+		func(p Props) (string, string) { return p.Size, p.Style },
+		cva.NewCompound(small, "link", "text-sm"),
+		cva.NewCompound("large", "link", "text-lg"),
+		cva.NewCompound("small", "link", "text-xs"), // want `duplicate compound entry \("small", "link"\) replaces the entry at .*a.go:36`
+	),
+	size.Is("medium").Then(""), // want `empty class string`
+	size.In("tiny", "large").Then("w-[10px", "grid-cols-[repeat(2,1fr)]"), // want `"tiny" is not one of` `unbalanced brackets in class "w-\[10px"`
+	cva.PredicateVariant(func(p Props) bool { return p.Count > 0 }, "  "), // want `empty class string`
+	cva.InSlot("icon", cva.Base[Props]("size-4"), style.Is("link").Then("size-4 px-4")),
+)
+
+func configureLate() *cva.Cva[Props] {
+	intent := cva.NewVariant(func(p Props) string { return p.Style })
+	danger := intent.Is("danger")
+	intent.WithDefault("primary")
+	intent.WithName("intent")
+
+	other := cva.NewVariant(func(p Props) int { return p.Count })
+	other.WithValues(1, 2)
+	c := cva.MustNew(
+		danger.Then("text-red-500"),
+		other.Map(map[int]string{1: "one", 3: "three"}), // want `3 is not one of the variant's values`
+		cva.Base[Props]("[mask-type:luminance]"),
+	)
+	other.WithDefault(1)    // want `Variant.WithDefault is called after the component at .*a.go:54:7 using the variant was created, and panics`
+	other.WithName("other") // want `Variant.WithName is called after the component at .*a.go:54:7 using the variant was created`
+	return c
+}
+
+type TaggedProps struct {
+	Size string `cva:"size"`
+}
+
+var tags = cva.MustParseTags[TaggedProps]()
+
+var Tagged = cva.New(
+	cva.Base[TaggedProps]("inline-flex"),
+	tags.Maps(map[string]map[string]string{
+		"size": {
+			"small": "h-8 inline-flex", // want `class "inline-flex" is already applied by Base`
+			"large": "w-[10px",         // want `unbalanced brackets in class "w-\[10px"`
+			"":      "",
+		},
+	}),
+)
@@ -0,0 +1,64 @@
+// Package cva is a stub of github.com/Roundaround/cva-go holding the declarations used by the
+// analyzer's test package.
+package cva
+
+type Cva[P any] struct{}
+
+type Option[P any] func(*Cva[P])
+
+func New[P any](opts ...Option[P]) *Cva[P] { return nil }
+
+func MustNew[P any](opts ...Option[P]) *Cva[P] { return nil }
+
+func Base[P any](classes ...string) Option[P] { return nil }
+
+func Label[P any](label string, opts ...Option[P]) Option[P] { return nil }
+
+func InSlot[P any](slot string, opts ...Option[P]) Option[P] { return nil }
+
+func MapVariant[P any, V comparable, S string | []string](getter func(P) V, m map[V]S) Option[P] {
+	return nil
+}
+
+func PredicateVariant[P any](test func(P) bool, classes ...string) Option[P] { return nil }
+
+type Compound[V1 comparable, V2 comparable] struct{}
+
+func NewCompound[V1 comparable, V2 comparable](v1 V1, v2 V2, classes ...string) Compound[V1, V2] {
+	return Compound[V1, V2]{}
+}
+
+func CompoundVariant[P any, V1 comparable, V2 comparable](
+	getter func(P) (V1, V2),
+	compounds ...Compound[V1, V2],
+) Option[P] {
+	return nil
+}
+
+type Matcher[P any] struct{}
+
+func (m Matcher[P]) Then(classes ...string) Option[P] { return nil }
+
+func (m Matcher[P]) And(others ...Matcher[P]) Matcher[P] { return m }
+
+type Variant[P any, V comparable] struct{}
+
+func NewVariant[P any, V comparable](getter func(P) V) *Variant[P, V] { return nil }
+
+func (v *Variant[P, V]) WithName(name string) *Variant[P, V] { return v }
+
+func (v *Variant[P, V]) WithDefault(val V) *Variant[P, V] { return v }
+
+func (v *Variant[P, V]) WithValues(vals ...V) *Variant[P, V] { return v }
+
+func (v *Variant[P, V]) Is(val V) Matcher[P] { return Matcher[P]{} }
+
+func (v *Variant[P, V]) In(vals ...V) Matcher[P] { return Matcher[P]{} }
+
+func (v *Variant[P, V]) Map(m map[V]string) Option[P] { return nil }
+
+type TagVariants[P any] struct{}
+
+func MustParseTags[P any]() *TagVariants[P] { return nil }
+
+func (t *TagVariants[P]) Maps(classes map[string]map[string]string) Option[P] { return nil }
@@ -0,0 +1,148 @@
+package cva
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefinitionProblem describes a single problem found in a component's definition by NewE.
+type DefinitionProblem struct {
+	// Message describes the problem.
+	Message string
+	// File and Line locate the code that created the faulty option, if known.
+	File string
+	Line int
+}
+
+// String returns a human-readable description of the problem.
+func (p DefinitionProblem) String() string {
+	if p.File == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("%s (%s:%d)", p.Message, p.File, p.Line)
+}
+
+// DefinitionError is returned by NewE when a component's definition has problems.
+type DefinitionError struct {
+	// Component is the component's name, as set with the Name option.
+	Component string
+	// Problems lists every problem found, in the order of the options causing them.
+	Problems []DefinitionProblem
+}
+
+// Error implements the error interface.
+func (e *DefinitionError) Error() string {
+	var b strings.Builder
+	b.WriteString("cva: invalid definition")
+	if e.Component != "" {
+		fmt.Fprintf(&b, " of component %q", e.Component)
+	}
+	b.WriteString(":")
+	for _, p := range e.Problems {
+		b.WriteString("\n\t")
+		b.WriteString(p.String())
+	}
+	return b.String()
+}
+
+// NewE creates a new Cva instance like New, but checks its definition for problems that New
+// silently accepts, returning a *DefinitionError listing all of them:
+//
+//   - nil getter, predicate and mapper functions, which would panic when generating classes
+//   - NewCompound entries replacing an earlier entry with the same values
+//   - Variant.Map keys, Variant.Is and Variant.In values and Variant.WithDefault values outside
+//     of the values set with Variant.WithValues, which are never applied
+//   - missing entries of options wrapped with Exhaustive, as reported by CheckExhaustive
+//
+// NewE is intended to be called once, during program initialization.
+func NewE[P any](opts ...Option[P]) (*Cva[P], error) {
+	c := New(opts...)
+	if problems := slices.Concat(c.problems, c.exhaustivenessProblems()); len(problems) > 0 {
+		return nil, &DefinitionError{Component: c.name, Problems: problems}
+	}
+	return c, nil
+}
+
+// MustNew is like NewE, but panics if the component's definition has problems.
+func MustNew[P any](opts ...Option[P]) *Cva[P] {
+	c, err := NewE(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// addProblems records definition problems found in the option created at the given location.
+// Problems already recorded, e.g. when the same option is applied twice, are skipped.
+func (c *Cva[P]) addProblems(file string, line int, messages ...string) {
+	for _, msg := range messages {
+		p := DefinitionProblem{Message: msg, File: file, Line: line}
+		if !slices.Contains(c.problems, p) {
+			c.problems = append(c.problems, p)
+		}
+	}
+}
+
+// problemsOf returns a function returning the given problems, or nil if there are none.
+func problemsOf(problems []string) func() []string {
+	if len(problems) == 0 {
+		return nil
+	}
+	return func() []string { return problems }
+}
+
+// nilProblem returns a problem if the named function passed to the option is nil.
+func nilProblem(option, name string, isNil bool) []string {
+	if !isNil {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: %s is nil", option, name)}
+}
+
+// describe returns the variant's name for use in problem messages.
+func (v *Variant[P, V]) describe() string {
+	if v.name == "" {
+		return "unnamed variant"
+	}
+	return fmt.Sprintf("variant %q", v.name)
+}
+
+// problems returns the problems of an option created with the given method of the variant, which
+// uses the given values.
+func (v *Variant[P, V]) problems(method string, vals ...V) []string {
+	problems := nilProblem("Variant."+method, "getter of "+v.describe(), v.getter == nil)
+	if v.values == nil {
+		return problems
+	}
+	if v.hasDefault && !slices.Contains(v.values, v.defaultVal) {
+		problems = append(problems, fmt.Sprintf(
+			"Variant.%s: default %#v of %s is not one of its values %v",
+			method, v.defaultVal, v.describe(), v.values,
+		))
+	}
+	var zero V
+	for _, val := range vals {
+		// Values outside of the variant's values are read as the zero value, which is thus
+		// always used.
+		if val != zero && !slices.Contains(v.values, val) {
+			problems = append(problems, fmt.Sprintf(
+				"Variant.%s: %#v is not one of the values %v of %s, and is never used",
+				method, val, v.values, v.describe(),
+			))
+		}
+	}
+	return problems
+}
+
+// duplicateCompoundProblem describes an exact compound entry replacing an earlier one.
+func duplicateCompoundProblem[K tuple](key K) string {
+	vals := key.values()
+	parts := make([]string, len(vals))
+	for i, v := range vals {
+		parts[i] = fmt.Sprintf("%#v", v)
+	}
+	return fmt.Sprintf(
+		"CompoundVariant: entry for (%s) replaces an earlier entry", strings.Join(parts, ", "),
+	)
+}
@@ -0,0 +1,136 @@
+package cva
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewE(t *testing.T) {
+	type Props struct {
+		Size  string
+		Style string
+	}
+
+	size := NewVariant(func(p Props) string { return p.Size }).
+		WithName("size").
+		WithValues("small", "medium", "large")
+	badDefault := NewVariant(func(p Props) string { return p.Style }).
+		WithValues("primary", "link").
+		WithDefault("ghost")
+	nilGetter := NewVariant[Props, string](nil).WithName("broken")
+
+	tests := []struct {
+		name string
+		opts []Option[Props]
+		want []string
+	}{
+		{
+			name: "valid",
+			opts: []Option[Props]{
+				Base[Props]("button"),
+				size.Map(map[string]string{"": "h-10", "small": "h-8", "large": "h-12"}),
+				size.Is("large").Or(size.In("small", "medium")).Then("rounded"),
+				CompoundVariant(
+					func(p Props) (string, string) { return p.Size, p.Style },
+					NewCompound("small", "link", "text-sm"),
+					NewCompoundOf(OneOf("small"), AnyValue[string](), "font-bold"),
+				),
+				DefaultVariant(func(p *Props) *string { return &p.Size }, "medium"),
+			},
+		},
+		{
+			name: "nil_functions",
+			opts: []Option[Props]{
+				Classes[Props, string](nil),
+				MapVariant[Props, string, string](nil, map[string]string{"a": "b"}),
+				PredicateVariant[Props](nil, "c"),
+				CompoundVariant[Props, string, string](nil),
+				DefaultVariants[Props](nil),
+				DefaultVariant[Props, string](nil, "medium"),
+				Inherit[Props, Props](nil, func(p Props) Props { return p }),
+				Inherit[Props, Props](New[Props](), nil),
+				nilGetter.Is("a").Then("d"),
+				size.Test(nil).Then("e"),
+			},
+			want: []string{
+				"Classes: getter is nil",
+				"MapVariant: getter is nil",
+				"PredicateVariant: predicate is nil",
+				"CompoundVariant: getter is nil",
+				"DefaultVariants: normalization function is nil",
+				"DefaultVariant: field function is nil",
+				"Inherit: base component is nil",
+				"Inherit: props mapper is nil",
+				`Variant.Is: getter of variant "broken" is nil`,
+				"Variant.Test: test function is nil",
+			},
+		},
+		{
+			name: "duplicate_compounds",
+			opts: []Option[Props]{
+				CompoundVariant(
+					func(p Props) (string, string) { return p.Size, p.Style },
+					NewCompound("small", "link", "text-sm"),
+					NewCompound("large", "link", "text-lg"),
+					NewCompound("small", "link", "text-xs"),
+				),
+			},
+			want: []string{`CompoundVariant: entry for ("small", "link") replaces an earlier entry`},
+		},
+		{
+			name: "outside_values",
+			opts: []Option[Props]{
+				InSlot("icon", size.Map(map[string]string{"small": "size-3", "huge": "size-8"})),
+				Label("style", badDefault.Is("primary").Then("bg-blue-500")),
+				size.Is("large").And(size.NotIn("tiny")).Then("shadow"),
+			},
+			want: []string{
+				`Variant.Map: "huge" is not one of the values [small medium large] of variant "size", and is never used`,
+				`Variant.Is: default "ghost" of unnamed variant is not one of its values [primary link]`,
+				`Variant.In: "tiny" is not one of the values [small medium large] of variant "size", and is never used`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := NewE(test.opts...)
+			if len(test.want) == 0 {
+				if err != nil || c == nil {
+					t.Errorf("got %v, %v, want a component and nil error", c, err)
+				}
+				return
+			}
+
+			var defErr *DefinitionError
+			if !errors.As(err, &defErr) {
+				t.Fatalf("got error %v, want a *DefinitionError", err)
+			}
+			if c != nil {
+				t.Errorf("got component %v, want nil", c)
+			}
+			var got []string
+			for _, p := range defErr.Problems {
+				if !strings.HasSuffix(p.File, "definition_test.go") {
+					t.Errorf("%s: got file %s, want definition_test.go", p.Message, p.File)
+				}
+				got = append(got, p.Message)
+			}
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	t.Run("MustNew", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			err, ok := r.(error)
+			if !ok || !strings.Contains(err.Error(), `cva: invalid definition of component "broken"`) {
+				t.Errorf("got panic %v, want a *DefinitionError", r)
+			}
+		}()
+		MustNew(Name[Props]("broken"), PredicateVariant[Props](nil, "c"))
+	})
+}
@@ -22,6 +22,12 @@ var Button = cva.New(
 	),
 )
 
+// DedupedButton inherits from Button, deduplicating its classes as part of the component
+var DedupedButton = cva.New(
+	cva.Inherit(Button, func(p Props) Props { return p }),
+	cva.WithMerger[Props](cva.Dedupe),
+)
+
 func DedupedClasses(p Props) string {
 	return cva.DedupeClasses(Button.Classes(p))
 }
@@ -32,4 +38,7 @@ func Example() {
 
 	fmt.Println(DedupedClasses(Props{"small"}))
 	// Output: inline-flex items-center justify-center rounded-md h-8
+
+	fmt.Println(DedupedButton.Classes(Props{"small"}))
+	// Output: inline-flex items-center justify-center rounded-md h-8
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/Roundaround/cva-go/examples/predicatevariants"
 	"github.com/Roundaround/cva-go/examples/simplecase"
 	"github.com/Roundaround/cva-go/examples/simplevariant"
+	"github.com/Roundaround/cva-go/examples/slots"
 	"github.com/Roundaround/cva-go/examples/templintegration"
 )
 
@@ -189,6 +190,11 @@ func TestExamples(t *testing.T) {
 				if got != test.want {
 					t.Errorf("got %s, want %s", got, test.want)
 				}
+
+				got = deduping.DedupedButton.Classes(deduping.Props{Size: test.size})
+				if got != test.want {
+					t.Errorf("DedupedButton: got %s, want %s", got, test.want)
+				}
 			})
 		}
 	})
@@ -328,6 +334,10 @@ func TestExamples(t *testing.T) {
 	})
 
 	t.Run("matchers", func(t *testing.T) {
+		if err := matchers.Button.CheckExhaustive(); err != nil {
+			t.Error(err)
+		}
+
 		// Note: Because twmerge.Merge is non-deterministic, we'll create a basic HTML string with the
 		// class attribute set directly as the output of twmerge.Merge to generate the expected outputs
 		// at runtime. Thankfully due to a caching mechanism in the twmerge package, the output of
@@ -555,6 +565,63 @@ func TestExamples(t *testing.T) {
 		}
 	})
 
+	t.Run("slots", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			size     string
+			closable bool
+			want     map[string]string
+		}{
+			{
+				name:     "small+closable",
+				size:     "small",
+				closable: true,
+				want: map[string]string{
+					"":       "fixed inset-0 flex items-center justify-center",
+					"panel":  "rounded-lg bg-white shadow-xl w-80",
+					"header": "flex items-center gap-2 px-3 py-2",
+					"footer": "flex items-center gap-2 px-3 py-2",
+					"close":  "",
+				},
+			},
+			{
+				name:     "default+!closable",
+				size:     "",
+				closable: false,
+				want: map[string]string{
+					"":       "fixed inset-0 flex items-center justify-center",
+					"panel":  "rounded-lg bg-white shadow-xl w-120",
+					"header": "flex items-center gap-2 px-6 py-4",
+					"footer": "flex items-center gap-2 px-6 py-4",
+					"close":  "hidden",
+				},
+			},
+			{
+				name:     "large+closable",
+				size:     "large",
+				closable: true,
+				want: map[string]string{
+					"":       "fixed inset-0 flex items-center justify-center",
+					"panel":  "rounded-lg bg-white shadow-xl w-200",
+					"header": "flex items-center gap-2 px-6 py-4",
+					"footer": "flex items-center gap-2 px-6 py-4",
+					"close":  "",
+				},
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := slots.Dialog.Slots(slots.Props{Size: test.size, Closable: test.closable})
+				for slot, want := range test.want {
+					if got.Get(slot) != want {
+						t.Errorf("slot %q: got %s, want %s", slot, got.Get(slot), want)
+					}
+				}
+			})
+		}
+	})
+
 	t.Run("templintegration", func(t *testing.T) {
 		// Note: Because twmerge.Merge is non-deterministic, we'll create a basic HTML string with the
 		// class attribute set directly as the output of twmerge.Merge to generate the expected outputs
@@ -631,3 +698,137 @@ func TestExamples(t *testing.T) {
 		}
 	})
 }
+
+func BenchmarkExamples(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		classes  func() string
+		appendTo func([]byte) []byte
+	}{
+		{
+			name: "additionalclasses",
+			classes: func() string {
+				return additionalclasses.Button.Classes(additionalclasses.Props{
+					Size:    "medium",
+					Classes: []string{"bg-red-500", "rounded-md"},
+				})
+			},
+			appendTo: func(dst []byte) []byte {
+				return additionalclasses.Button.AppendClasses(dst, additionalclasses.Props{
+					Size:    "medium",
+					Classes: []string{"bg-red-500", "rounded-md"},
+				})
+			},
+		},
+		{
+			name: "compoundvariants",
+			classes: func() string {
+				return compoundvariants.Button.Classes(
+					compoundvariants.Props{Size: "small", Style: "icon"},
+				)
+			},
+			appendTo: func(dst []byte) []byte {
+				return compoundvariants.Button.AppendClasses(
+					dst,
+					compoundvariants.Props{Size: "small", Style: "icon"},
+				)
+			},
+		},
+		{
+			name: "deduping",
+			classes: func() string {
+				return deduping.DedupedButton.Classes(deduping.Props{Size: "medium"})
+			},
+			appendTo: func(dst []byte) []byte {
+				return deduping.DedupedButton.AppendClasses(dst, deduping.Props{Size: "medium"})
+			},
+		},
+		{
+			name: "inheritance",
+			classes: func() string {
+				return inheritance.LoadingButton.Classes(inheritance.LoadingButtonProps{
+					ButtonProps: inheritance.ButtonProps{Size: "large", Style: "primary"},
+					Loading:     true,
+				})
+			},
+			appendTo: func(dst []byte) []byte {
+				return inheritance.LoadingButton.AppendClasses(dst, inheritance.LoadingButtonProps{
+					ButtonProps: inheritance.ButtonProps{Size: "large", Style: "primary"},
+					Loading:     true,
+				})
+			},
+		},
+		{
+			name: "matchers",
+			classes: func() string {
+				return matchers.Button.Classes(matchers.Props{
+					Size:    matchers.SizeLarge,
+					Theme:   matchers.ThemePrimary,
+					Element: matchers.ElementIcon,
+				})
+			},
+			appendTo: func(dst []byte) []byte {
+				return matchers.Button.AppendClasses(dst, matchers.Props{
+					Size:    matchers.SizeLarge,
+					Theme:   matchers.ThemePrimary,
+					Element: matchers.ElementIcon,
+				})
+			},
+		},
+		{
+			name: "predicatevariants",
+			classes: func() string {
+				return predicatevariants.Button.Classes(predicatevariants.Props{Loading: true})
+			},
+			appendTo: func(dst []byte) []byte {
+				return predicatevariants.Button.AppendClasses(
+					dst,
+					predicatevariants.Props{Loading: true},
+				)
+			},
+		},
+		{
+			name: "simplecase",
+			classes: func() string {
+				return simplecase.Button.Classes(simplecase.Props{Size: "medium"})
+			},
+			appendTo: func(dst []byte) []byte {
+				return simplecase.Button.AppendClasses(dst, simplecase.Props{Size: "medium"})
+			},
+		},
+		{
+			name: "simplevariant",
+			classes: func() string {
+				return simplevariant.Button.Classes(simplevariant.Props{Size: "medium"})
+			},
+			appendTo: func(dst []byte) []byte {
+				return simplevariant.Button.AppendClasses(dst, simplevariant.Props{Size: "medium"})
+			},
+		},
+		{
+			name: "slots",
+			classes: func() string {
+				return slots.Dialog.Classes(slots.Props{Size: "large", Closable: true})
+			},
+			appendTo: func(dst []byte) []byte {
+				return slots.Dialog.AppendClasses(dst, slots.Props{Size: "large", Closable: true})
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name+"/Classes", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				bm.classes()
+			}
+		})
+		b.Run(bm.name+"/AppendClasses", func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 256)
+			for b.Loop() {
+				buf = bm.appendTo(buf[:0])
+			}
+		})
+	}
+}
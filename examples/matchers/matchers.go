@@ -41,15 +41,21 @@ var Button = cva.New(
 	// Apply classes no matter what
 	cva.Base[Props]("px-4 py-1"),
 
-	// Apply classes based on 1:1 mappings to variant values
-	size.Map(map[Size]string{
-		SizeSmall: "px-2",
-		SizeLarge: "px-6 py-2",
-	}),
-	theme.Map(map[Theme]string{
-		ThemeDanger:  "bg-red-500 text-white",
-		ThemePrimary: "bg-blue-500 text-white",
-	}),
+	// Apply classes based on 1:1 mappings to variant values, requiring an entry for every value
+	cva.Exhaustive(
+		[]cva.Domain{cva.NewDomain(SizeSmall, SizeLarge)},
+		size.Map(map[Size]string{
+			SizeSmall: "px-2",
+			SizeLarge: "px-6 py-2",
+		}),
+	),
+	cva.Exhaustive(
+		[]cva.Domain{cva.NewDomain(ThemeDanger, ThemePrimary)},
+		theme.Map(map[Theme]string{
+			ThemeDanger:  "bg-red-500 text-white",
+			ThemePrimary: "bg-blue-500 text-white",
+		}),
+	),
 
 	// Construct matchers using Variant.* (Is, In, IsNot, NotIn, or Test)
 	elem.Is(ElementIcon).Then("rounded-full"),
@@ -0,0 +1,52 @@
+package slots
+
+import (
+	"fmt"
+
+	"github.com/Roundaround/cva-go"
+)
+
+type Props struct {
+	Size     string
+	Closable bool
+}
+
+var size = cva.NewVariant(func(p Props) string { return p.Size }).WithDefault("medium")
+
+var Dialog = cva.New(
+	// Root element
+	cva.Base[Props]("fixed inset-0 flex items-center justify-center"),
+
+	// Target individual slots
+	cva.InSlot("panel",
+		cva.Base[Props]("rounded-lg bg-white shadow-xl"),
+		size.Map(map[string]string{
+			"small":  "w-80",
+			"medium": "w-120",
+			"large":  "w-200",
+		}),
+	),
+	cva.InSlot("close",
+		cva.PredicateVariant(func(p Props) bool { return !p.Closable }, "hidden"),
+	),
+
+	// Or several slots at once
+	cva.InSlots([]string{"header", "footer"},
+		cva.Base[Props]("flex items-center gap-2"),
+		size.Is("small").Then("px-3 py-2"),
+		size.IsNot("small").Then("px-6 py-4"),
+	),
+)
+
+func Example() {
+	slots := Dialog.Slots(Props{Size: "small", Closable: true})
+	fmt.Println(slots.Get(""))
+	fmt.Println(slots.Get("panel"))
+	fmt.Println(slots.Get("header"))
+	fmt.Println(slots.Get("close"))
+	// Output:
+	// fixed inset-0 flex items-center justify-center
+	// rounded-lg bg-white shadow-xl w-80
+	// flex items-center gap-2 px-3 py-2
+	//
+}
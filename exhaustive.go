@@ -0,0 +1,189 @@
+package cva
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Domain is the complete list of values of a variant, declared with NewDomain for use with
+// Exhaustive.
+type Domain struct {
+	values []any
+}
+
+// NewDomain declares the complete list of values of a variant, e.g. every constant of an enum
+// type.
+//
+// The values must have the variant's value type: untyped constants default to their basic type, so
+// NewDomain(0, 1) declares int values, while NewDomain[Size](0, 1) declares Size values. Exhaustive
+// reports domains holding values of another type instead of comparing them with the option's
+// cases.
+func NewDomain[V comparable](vals ...V) Domain {
+	return Domain{values: toAny(vals)}
+}
+
+// Values returns the domain's values.
+func (d Domain) Values() []any {
+	return slices.Clone(d.values)
+}
+
+// Exhaustive requires the given map and compound options to cover every value of their variants'
+// domains, as reported by NewE and CheckExhaustive.
+//
+// The domains give the complete list of values of each of the options' variants, in order: the
+// single variant of map options, or each position of compound options. A zero Domain, or a missing
+// one, falls back to the values set with Variant.WithValues. Map options must have an entry for
+// every value of their variant's domain, and compound options an entry matching every combination
+// of values of their positions' domains.
+func Exhaustive[P any](domains []Domain, opts ...Option[P]) Option[P] {
+	return func(c *Cva[P]) {
+		inner := New(opts...)
+		c.absorb(inner)
+		for _, p := range inner.producers {
+			p.info.Exhaustive = true
+			p.info.Variants = slices.Clone(p.info.Variants)
+			for i, d := range domains {
+				if i < len(p.info.Variants) && d.values != nil {
+					p.info.Variants[i].Values = d.values
+					p.info.Variants[i].declared = true
+				}
+			}
+			c.addProducer(p)
+		}
+	}
+}
+
+// CheckExhaustive checks that every option wrapped with Exhaustive covers its variants' domains,
+// returning a *DefinitionError listing every missing map entry and compound combination, or nil.
+//
+// NewE performs the same checks, but CheckExhaustive can also be called from unit tests on
+// components created with New.
+func (c *Cva[P]) CheckExhaustive() error {
+	problems := c.exhaustivenessProblems()
+	if len(problems) == 0 {
+		return nil
+	}
+	return &DefinitionError{Component: c.name, Problems: problems}
+}
+
+// exhaustivenessProblems returns the problems found by CheckExhaustive.
+func (c *Cva[P]) exhaustivenessProblems() []DefinitionProblem {
+	var problems []DefinitionProblem
+	seen := make(map[*OptionSchema]bool)
+	for _, p := range c.producers {
+		if seen[p.info] || !p.info.Exhaustive {
+			continue
+		}
+		seen[p.info] = true
+		for _, msg := range missingCases(p.info) {
+			problems = append(problems, DefinitionProblem{
+				Message: msg,
+				File:    p.info.File,
+				Line:    p.info.Line,
+			})
+		}
+	}
+	return problems
+}
+
+// missingCases describes every combination of the option's variants' values not covered by any of
+// its cases.
+func missingCases(info *OptionSchema) []string {
+	if info.Kind != KindMap && info.Kind != KindCompound {
+		return []string{fmt.Sprintf("Exhaustive: %s options cannot be exhaustive", info.Kind)}
+	}
+
+	var problems []string
+	for i, v := range info.Variants {
+		if !v.declared {
+			problems = append(problems, fmt.Sprintf(
+				"Exhaustive: no domain declared for %s", describeVariant(info, i),
+			))
+			continue
+		}
+		if v.typ == nil {
+			continue
+		}
+		for _, val := range v.Values {
+			if typ := reflect.TypeOf(val); !assignable(typ, v.typ) {
+				problems = append(problems, fmt.Sprintf(
+					"Exhaustive: domain value %v of %s has type %v, want %s",
+					val, describeVariant(info, i), typ, v.typ,
+				))
+				break
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	combination := make([]any, len(info.Variants))
+	var walk func(pos int)
+	walk = func(pos int) {
+		if pos == len(combination) {
+			if !slices.ContainsFunc(info.Cases, func(cs CaseSchema) bool {
+				return caseMatches(cs, combination)
+			}) {
+				problems = append(problems, describeMissing(info, combination))
+			}
+			return
+		}
+		for _, val := range info.Variants[pos].Values {
+			combination[pos] = val
+			walk(pos + 1)
+		}
+	}
+	walk(0)
+	return problems
+}
+
+// assignable reports whether a domain value of type typ, nil for a nil interface value, can be a
+// value of a variant of type want.
+func assignable(typ, want reflect.Type) bool {
+	if typ == nil {
+		return want.Kind() == reflect.Interface
+	}
+	return typ.AssignableTo(want)
+}
+
+// caseMatches reports whether the case matches the given combination of values.
+func caseMatches(cs CaseSchema, combination []any) bool {
+	for i, val := range combination {
+		if cs.Values[i] != nil && !slices.Contains(cs.Values[i], val) {
+			return false
+		}
+	}
+	return true
+}
+
+// describeVariant returns the name of the option's i-th variant, for use in problem messages.
+func describeVariant(info *OptionSchema, i int) string {
+	name := info.Variants[i].Name
+	if name == "" && info.Kind == KindMap {
+		name = info.Label
+	}
+	if name == "" {
+		if info.Kind == KindCompound {
+			return fmt.Sprintf("position %d", i+1)
+		}
+		return "unnamed variant"
+	}
+	return fmt.Sprintf("variant %q", name)
+}
+
+// describeMissing describes a combination of values not covered by the option's cases.
+func describeMissing(info *OptionSchema, combination []any) string {
+	if info.Kind == KindMap {
+		return fmt.Sprintf(
+			"Exhaustive: missing map entry for %#v of %s", combination[0], describeVariant(info, 0),
+		)
+	}
+	parts := make([]string, len(combination))
+	for i, val := range combination {
+		parts[i] = fmt.Sprintf("%#v", val)
+	}
+	return fmt.Sprintf("Exhaustive: missing compound entry for (%s)", strings.Join(parts, ", "))
+}
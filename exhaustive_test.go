@@ -0,0 +1,130 @@
+package cva
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExhaustive(t *testing.T) {
+	type Size int
+	const (
+		Small Size = iota
+		Medium
+		Large
+	)
+	type Props struct {
+		Size  Size
+		Style string
+	}
+
+	sizes := NewDomain(Small, Medium, Large)
+	styles := NewDomain("primary", "link")
+	size := NewVariant(func(p Props) Size { return p.Size }).WithName("size").WithValues(Small, Medium, Large)
+	getSize := func(p Props) Size { return p.Size }
+	getBoth := func(p Props) (Size, string) { return p.Size, p.Style }
+
+	tests := []struct {
+		name string
+		opts []Option[Props]
+		want []string
+	}{
+		{
+			name: "complete",
+			opts: []Option[Props]{
+				Exhaustive([]Domain{sizes}, MapVariant(getSize, map[Size]string{Small: "h-8", Medium: "h-10", Large: "h-12"})),
+				Exhaustive(nil, size.Map(map[Size]string{Small: "h-8", Medium: "h-10", Large: "h-12"})),
+				Exhaustive([]Domain{sizes, styles}, CompoundVariant(getBoth,
+					NewCompoundOf(OneOf(Small, Medium), AnyValue[string](), "a"),
+					NewCompound(Large, "primary", "b"),
+					NewCompound(Large, "link", "c"),
+				)),
+			},
+		},
+		{
+			name: "not_exhaustive",
+			opts: []Option[Props]{
+				MapVariant(getSize, map[Size]string{Small: "h-8"}),
+				size.Map(map[Size]string{Small: "h-8"}),
+			},
+		},
+		{
+			name: "missing",
+			opts: []Option[Props]{
+				Label("size", Exhaustive([]Domain{sizes}, MapVariant(getSize, map[Size]string{Small: "h-8"}))),
+				Exhaustive(nil, size.Map(map[Size]string{Small: "h-8", Large: "h-12"})),
+				Exhaustive([]Domain{{}, styles}, CompoundVariant(getBoth,
+					NewCompoundOf(OneOf(Small, Medium), AnyValue[string](), "a"),
+				)),
+				Exhaustive([]Domain{sizes, styles}, CompoundVariant(getBoth,
+					NewCompoundOf(OneOf(Small, Medium), AnyValue[string](), "a"),
+					NewCompound(Large, "link", "c"),
+				)),
+			},
+			want: []string{
+				`Exhaustive: missing map entry for 1 of variant "size"`,
+				`Exhaustive: missing map entry for 2 of variant "size"`,
+				`Exhaustive: missing map entry for 1 of variant "size"`,
+				`Exhaustive: no domain declared for position 1`,
+				`Exhaustive: missing compound entry for (2, "primary")`,
+			},
+		},
+		{
+			name: "untyped_domain",
+			opts: []Option[Props]{
+				Exhaustive([]Domain{NewDomain[Size](0, 1, 2)}, MapVariant(getSize, map[Size]string{0: "h-8", 1: "h-10", 2: "h-12"})),
+				Exhaustive([]Domain{NewDomain(0, 1)}, MapVariant(getSize, map[Size]string{0: "h-8", 1: "h-10"})),
+				Exhaustive([]Domain{sizes, NewDomain(0)}, CompoundVariant(getBoth,
+					NewCompoundOf(AnyValue[Size](), AnyValue[string](), "a"),
+				)),
+			},
+			want: []string{
+				`Exhaustive: domain value 0 of unnamed variant has type int, want cva.Size`,
+				`Exhaustive: domain value 0 of position 2 has type int, want string`,
+			},
+		},
+		{
+			name: "unsupported",
+			opts: []Option[Props]{
+				Exhaustive(nil, MapVariant(getSize, map[Size]string{Small: "h-8"}), Base[Props]("base")),
+			},
+			want: []string{
+				`Exhaustive: no domain declared for unnamed variant`,
+				`Exhaustive: base options cannot be exhaustive`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checks := map[string]func() error{
+				"CheckExhaustive": func() error { return New(test.opts...).CheckExhaustive() },
+				"NewE": func() error {
+					_, err := NewE(test.opts...)
+					return err
+				},
+			}
+			for name, check := range checks {
+				err := check()
+				if len(test.want) == 0 {
+					if err != nil {
+						t.Errorf("%s: got error %v, want nil", name, err)
+					}
+					continue
+				}
+
+				var defErr *DefinitionError
+				if !errors.As(err, &defErr) {
+					t.Fatalf("%s: got error %v, want a *DefinitionError", name, err)
+				}
+				var got []string
+				for _, p := range defErr.Problems {
+					got = append(got, p.Message)
+				}
+				if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+					t.Errorf("%s: got %q, want %q", name, got, test.want)
+				}
+			}
+		})
+	}
+}
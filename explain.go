@@ -0,0 +1,208 @@
+package cva
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Explanation describes how a component's class list was generated for a given set of props, as
+// returned by Cva.Explain.
+type Explanation struct {
+	// Slot is the explained slot, or empty for the root element.
+	Slot string
+	// Classes is the generated class list, identical to the output of Classes or Slots.
+	Classes string
+	// Tokens lists every class token produced by the component's options, in order, including
+	// tokens later dropped by the component's merger.
+	Tokens []Trace
+}
+
+// Trace describes a single class token produced by one of a component's options.
+type Trace struct {
+	// Class is the class token.
+	Class string
+	// Origin describes the option which produced the token.
+	Origin Origin
+	// Dropped reports whether the token was removed from the final class list by the component's
+	// merger.
+	Dropped bool
+}
+
+// Origin describes the option which produced a class token.
+type Origin struct {
+	// Kind is the type of the option.
+	Kind Kind
+	// Label is the option's label, as set with the Label option.
+	Label string
+	// Values lists the variant values which selected the token, for map and compound options. For
+	// compound options there is one value per position of the compound tuple.
+	Values []any
+	// Variants lists the names of the variants corresponding to each of the Values, which are
+	// empty for unnamed variants.
+	Variants []string
+	// Condition describes the logic of predicate options.
+	Condition *Condition
+	// Inherited lists the names of the components the option was inherited from through Inherit,
+	// starting with the component inherited from directly. It is empty for the component's own
+	// options.
+	Inherited []string
+	// File and Line locate the code that created the option, if known.
+	File string
+	Line int
+}
+
+// String returns a human-readable description of the origin, e.g.
+// `map size="large" (button.go:12)`.
+func (o Origin) String() string {
+	var b strings.Builder
+	b.WriteString(o.Kind.String())
+	if o.Label != "" {
+		fmt.Fprintf(&b, " %q", o.Label)
+	}
+	if len(o.Values) > 0 {
+		vals := make([]string, len(o.Values))
+		for i, v := range o.Values {
+			vals[i] = formatValue(v)
+			if i < len(o.Variants) && o.Variants[i] != "" {
+				vals[i] = o.Variants[i] + "=" + vals[i]
+			}
+		}
+		b.WriteString(" " + strings.Join(vals, ", "))
+	}
+	if o.Condition != nil {
+		b.WriteString(" when " + o.Condition.String())
+	}
+	for _, name := range o.Inherited {
+		if name == "" {
+			name = "component"
+		}
+		b.WriteString(" from " + name)
+	}
+	if o.File != "" {
+		fmt.Fprintf(&b, " (%s:%d)", filepath.Base(o.File), o.Line)
+	}
+	return b.String()
+}
+
+// Dropped returns the traces of the tokens removed by the component's merger.
+func (e Explanation) Dropped() []Trace {
+	var dropped []Trace
+	for _, t := range e.Tokens {
+		if t.Dropped {
+			dropped = append(dropped, t)
+		}
+	}
+	return dropped
+}
+
+// String returns a human-readable description of the explanation, listing each token on its own
+// line along with its origin.
+func (e Explanation) String() string {
+	width := 0
+	for _, t := range e.Tokens {
+		width = max(width, len(t.Class))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q\n", e.Classes)
+	for _, t := range e.Tokens {
+		mark := " "
+		if t.Dropped {
+			mark = "-"
+		}
+		fmt.Fprintf(&b, "%s %-*s  %s\n", mark, width, t.Class, t.Origin)
+	}
+	return b.String()
+}
+
+// Explain generates the class list for the root element of the component like Classes, and
+// reports which option produced each class token and which tokens were dropped by the merger.
+//
+// Explain is intended for debugging, and is considerably slower than Classes.
+func (c *Cva[P]) Explain(props P) Explanation {
+	return c.ExplainSlot(props, "")
+}
+
+// ExplainSlot is like Explain, but for the given slot of a multi-part component.
+func (c *Cva[P]) ExplainSlot(props P, slot string) Explanation {
+	props = c.normalize(props)
+
+	e := Explanation{Slot: slot}
+	for _, p := range c.producers {
+		if p.slot != slot {
+			continue
+		}
+		classes, origin := p.explain(props)
+		for _, class := range classes {
+			for _, token := range strings.Fields(class) {
+				e.Tokens = append(e.Tokens, Trace{Class: token, Origin: origin})
+			}
+		}
+	}
+	e.Classes = c.slotClasses(props, slot)
+
+	// Tokens are matched against the final class list by count, so when a token occurs several
+	// times, its first occurrences are considered kept.
+	kept := make(map[string]int)
+	for _, token := range strings.Fields(e.Classes) {
+		kept[token]++
+	}
+	for i, t := range e.Tokens {
+		if kept[t.Class] > 0 {
+			kept[t.Class]--
+		} else {
+			e.Tokens[i].Dropped = true
+		}
+	}
+	return e
+}
+
+// explain returns the producer's output along with a description of its origin.
+func (p producer[P]) explain(props P) ([]string, Origin) {
+	if p.trace != nil {
+		return p.trace(props)
+	}
+
+	classes := p.fn(props)
+	origin := Origin{
+		Kind:      p.info.Kind,
+		Label:     p.info.Label,
+		Condition: p.info.Condition,
+		File:      p.info.File,
+		Line:      p.info.Line,
+	}
+	if p.values != nil && len(classes) > 0 {
+		origin.Values = p.values(props)
+		origin.Variants = make([]string, len(p.info.Variants))
+		for i, v := range p.info.Variants {
+			origin.Variants[i] = v.Name
+		}
+	}
+	return classes, origin
+}
+
+// packageDir is the directory of this package's source files, used to skip its own frames when
+// locating the code creating an option.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerLocation returns the location of the innermost caller outside of this package, i.e. the
+// code calling one of the package's option constructors.
+func callerLocation() (string, int) {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
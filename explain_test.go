@@ -0,0 +1,139 @@
+package cva
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	type Props struct {
+		Size     string
+		Disabled bool
+	}
+
+	_, file, line, _ := runtime.Caller(0)
+
+	size := NewVariant(func(p Props) string { return p.Size }).WithName("size")
+
+	base := New(
+		Name[Props]("base"),
+		Base[Props]("px-4"),
+	)
+
+	button := New(
+		Inherit(base, func(p Props) Props { return p }),
+		Label("size", MapVariant(
+			func(p Props) string { return p.Size },
+			map[string]string{"small": "h-8 px-2", "large": "h-12"},
+		)),
+		CompoundVariant(
+			func(p Props) (string, bool) { return p.Size, p.Disabled },
+			NewCompound("small", true, "opacity-75"),
+		),
+		size.Is("small").Then("text-sm"),
+		InSlot("icon", Base[Props]("size-4")),
+		WithMerger[Props](MergerFunc(func(classes ...string) string {
+			return strings.Replace(strings.Join(classes, " "), "px-4 ", "", 1)
+		})),
+	)
+
+	e := button.Explain(Props{Size: "small", Disabled: true})
+
+	if want := "h-8 px-2 opacity-75 text-sm"; e.Classes != want {
+		t.Errorf("Classes: got %s, want %s", e.Classes, want)
+	}
+
+	type token struct {
+		class   string
+		kind    Kind
+		values  []any
+		dropped bool
+	}
+	wantTokens := []token{
+		{class: "px-4", kind: KindBase, dropped: true},
+		{class: "h-8", kind: KindMap, values: []any{"small"}},
+		{class: "px-2", kind: KindMap, values: []any{"small"}},
+		{class: "opacity-75", kind: KindCompound, values: []any{"small", true}},
+		{class: "text-sm", kind: KindPredicate},
+	}
+	if len(e.Tokens) != len(wantTokens) {
+		t.Fatalf("got %d tokens, want %d", len(e.Tokens), len(wantTokens))
+	}
+	for i, want := range wantTokens {
+		got := e.Tokens[i]
+		if got.Class != want.class || got.Origin.Kind != want.kind ||
+			!reflect.DeepEqual(got.Origin.Values, want.values) || got.Dropped != want.dropped {
+			t.Errorf(
+				"token %d: got %s %s %v %v, want %s %s %v %v",
+				i,
+				got.Class, got.Origin.Kind, got.Origin.Values, got.Dropped,
+				want.class, want.kind, want.values, want.dropped,
+			)
+		}
+	}
+
+	t.Run("inherited", func(t *testing.T) {
+		if got := e.Tokens[0].Origin.Inherited; !reflect.DeepEqual(got, []string{"base"}) {
+			t.Errorf("got %v, want %v", got, []string{"base"})
+		}
+	})
+
+	t.Run("label", func(t *testing.T) {
+		if got := e.Tokens[1].Origin.Label; got != "size" {
+			t.Errorf("got %q, want %q", got, "size")
+		}
+	})
+
+	t.Run("condition", func(t *testing.T) {
+		cond := e.Tokens[4].Origin.Condition
+		if cond == nil || cond.String() != `size == "small"` {
+			t.Errorf("got %v, want %s", cond, `size == "small"`)
+		}
+	})
+
+	t.Run("location", func(t *testing.T) {
+		origin := e.Tokens[0].Origin
+		if origin.File != file || origin.Line != line+6 {
+			t.Errorf("got %s:%d, want %s:%d", origin.File, origin.Line, file, line+6)
+		}
+		origin = e.Tokens[1].Origin
+		if origin.File != file || origin.Line != line+11 {
+			t.Errorf("got %s:%d, want %s:%d", origin.File, origin.Line, file, line+11)
+		}
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		dropped := e.Dropped()
+		if len(dropped) != 1 || dropped[0].Class != "px-4" {
+			t.Errorf("got %v, want px-4", dropped)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		got := e.Tokens[3].Origin.String()
+		want := fmt.Sprintf(
+			`compound "small", true (%s:%d)`,
+			filepath.Base(file), e.Tokens[3].Origin.Line,
+		)
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+
+		got = e.Tokens[0].Origin.String()
+		want = fmt.Sprintf("base from base (%s:%d)", filepath.Base(file), line+6)
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("slot", func(t *testing.T) {
+		e := button.ExplainSlot(Props{}, "icon")
+		if e.Classes != "size-4" || len(e.Tokens) != 1 || e.Tokens[0].Origin.Kind != KindBase {
+			t.Errorf("got %+v, want a single size-4 base token", e)
+		}
+	})
+}
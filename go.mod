@@ -1,3 +1,13 @@
 module github.com/Roundaround/cva-go
 
 go 1.24.2
+
+require (
+	golang.org/x/tools v0.32.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/mod v0.24.0 // indirect
+	golang.org/x/sync v0.13.0 // indirect
+)
@@ -1,32 +1,24 @@
 package cva
 
 import (
-	"regexp"
+	"bytes"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
-var whitespaceRe = regexp.MustCompile(`\s+`)
-
 // Memoize returns a memoized version of the given function.
 //
-// The memoized function will cache the result of the first call for each unique
-// input value. This is useful for expensive computations or when the same input
-// is likely to be used multiple times. Might be useful for prop getters or
-// transformers that for some reason are expensive to compute or involve copying
-// data.
+// The memoized function will cache the result of the last call, and reuse it for as
+// long as it is called with the same input value. This is useful for expensive
+// computations or when the same input is likely to be used multiple times. Might be
+// useful for prop getters or transformers that for some reason are expensive to
+// compute or involve copying data.
+//
+// The memoized function is safe for concurrent use. To cache more than one result,
+// or to memoize functions of non-comparable inputs, use NewMemo or NewMemoFunc.
 func Memoize[P comparable, R any](fn func(P) R) func(P) R {
-	var lastProps P
-	var lastResult R
-	var hasLast bool
-
-	return func(p P) R {
-		if !hasLast || lastProps != p {
-			lastProps = p
-			lastResult = fn(p)
-			hasLast = true
-		}
-		return lastResult
-	}
+	return NewMemo(1, fn).Func()
 }
 
 // DedupeClasses deduplicates classes from the given list and joins them all
@@ -59,6 +51,98 @@ func DedupeClasses(classes ...string) string {
 
 // JoinClasses joins the given classes together with spaces, trims whitespace, and converts all
 // whitespace to single spaces.
+//
+// Classes are separated by spaces, tabs, newlines, carriage returns and form feeds. Other Unicode
+// whitespace, such as vertical tabs and non-breaking spaces, is only trimmed from either end of the
+// joined class list.
 func JoinClasses(classes ...string) string {
-	return whitespaceRe.ReplaceAllString(strings.TrimSpace(strings.Join(classes, " ")), " ")
+	if len(classes) == 1 && isNormalized(classes[0]) {
+		return classes[0]
+	}
+
+	n := 0
+	for _, c := range classes {
+		n += len(c) + 1
+	}
+	buf := make([]byte, 0, n)
+	for _, c := range classes {
+		buf = appendTokens(buf, c)
+	}
+	return string(trimSeparator(buf, 0))
+}
+
+// isSpace reports whether the byte is whitespace separating classes: the ASCII whitespace matched
+// by \s in regular expressions, which excludes \v.
+func isSpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\f', '\r':
+		return true
+	}
+	return false
+}
+
+// mayTrim reports whether the byte may start or end whitespace that only separates classes when at
+// either end of a class list, such as \v or a non-breaking space, which strings.TrimSpace trims.
+func mayTrim(b byte) bool {
+	return b == '\v' || b >= utf8.RuneSelf
+}
+
+// isNormalized reports whether the class list is already normalized, i.e. holds no leading,
+// trailing or repeated whitespace and no whitespace other than single spaces.
+func isNormalized(classes string) bool {
+	if n := len(classes); n > 0 && (mayTrim(classes[0]) || mayTrim(classes[n-1])) {
+		return false
+	}
+	for i := 0; i < len(classes); i++ {
+		if b := classes[i]; isSpace(b) {
+			if b != ' ' || i == 0 || i == len(classes)-1 || classes[i-1] == ' ' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// appendTokens appends each of the whitespace-separated tokens of the class list to dst,
+// preceding each of them with a single space.
+func appendTokens(dst []byte, classes string) []byte {
+	if isNormalized(classes) {
+		return appendNormalized(dst, classes)
+	}
+	start := -1
+	for i := 0; i <= len(classes); i++ {
+		if i == len(classes) || isSpace(classes[i]) {
+			if start >= 0 {
+				dst = append(dst, ' ')
+				dst = append(dst, classes[start:i]...)
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	return dst
+}
+
+// appendNormalized appends an already normalized class list to dst, preceded by a single space.
+func appendNormalized(dst []byte, classes string) []byte {
+	if classes == "" {
+		return dst
+	}
+	dst = append(dst, ' ')
+	return append(dst, classes...)
+}
+
+// trimSeparator removes the space preceding the first token appended to dst after start, along with
+// any Unicode whitespace at either end of the appended class list, as strings.TrimSpace would.
+func trimSeparator(dst []byte, start int) []byte {
+	if len(dst) == start {
+		return dst
+	}
+	classes := dst[start+1:]
+	if n := len(classes); n > 0 && (mayTrim(classes[0]) || mayTrim(classes[n-1])) {
+		classes = bytes.TrimFunc(classes, unicode.IsSpace)
+	}
+	n := copy(dst[start:], classes)
+	return dst[:start+n]
 }
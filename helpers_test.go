@@ -2,6 +2,7 @@ package cva
 
 import (
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -121,6 +122,25 @@ func TestMemoize(t *testing.T) {
 			t.Errorf("callCount = %d, want %d", callCount, 2)
 		}
 	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		memoizedFn := Memoize(func(x int) int { return x * 2 })
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 100 {
+					x := (i + j) % 2
+					if got := memoizedFn(x); got != x*2 {
+						t.Errorf("got %d, want %d", got, x*2)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
 }
 
 func TestDedupeClasses(t *testing.T) {
@@ -193,6 +213,30 @@ func TestJoinClasses(t *testing.T) {
 			classes: []string{" start tab	 space  end\n"},
 			want:    "start tab space end",
 		},
+		{
+			classes: []string{"already normalized"},
+			want:    "already normalized",
+		},
+		{
+			classes: []string{"", "a\r\n\v\fb", "  ", "c"},
+			want:    "a \v b c",
+		},
+		{
+			classes: []string{"\va\vb\v"},
+			want:    "a\vb",
+		},
+		{
+			classes: []string{"\u00a0 a", "b\u00a0", "\u00a0"},
+			want:    "a b",
+		},
+		{
+			classes: []string{"a", "b\u00a0"},
+			want:    "a b",
+		},
+		{
+			classes: []string{"unicode-☃", "\tpre\u00a0space"},
+			want:    "unicode-☃ pre\u00a0space",
+		},
 	}
 
 	for i, test := range tests {
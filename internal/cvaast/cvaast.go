@@ -0,0 +1,72 @@
+// Package cvaast recognizes calls to the functions and methods of github.com/Roundaround/cva-go in
+// type-checked syntax trees, for the tools inspecting component definitions statically.
+package cvaast
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// Path is the import path of the cva package.
+const Path = "github.com/Roundaround/cva-go"
+
+// ClassArgs maps each cva function or method to the index of the first argument holding class
+// lists. All arguments from that index onwards are class lists, or composite literals holding class
+// lists, possibly nested as with the maps of maps passed to TagVariants.Maps.
+var ClassArgs = map[string]int{
+	"Base":             0,
+	"Static":           0,
+	"MapVariant":       1,
+	"PredicateVariant": 1,
+	"NewCompound":      2,
+	"NewCompoundOf":    2,
+	"NewCompound3":     3,
+	"NewCompound3Of":   3,
+	"NewCompound4":     4,
+	"NewCompound4Of":   4,
+	"When":             1,
+	"Variant.Map":      0,
+	"Matcher.Then":     0,
+	"TagVariants.Maps": 0,
+}
+
+// CalleeName returns the name of the cva function or method called by fun, e.g. "Base" or
+// "Variant.Map", or an empty string if fun does not refer to the cva package.
+func CalleeName(info *types.Info, fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		return CalleeName(info, f.X)
+	case *ast.IndexListExpr:
+		return CalleeName(info, f.X)
+	case *ast.ParenExpr:
+		return CalleeName(info, f.X)
+	}
+
+	var id *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return ""
+	}
+
+	fn, ok := info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != Path {
+		return ""
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if recv := sig.Recv(); recv != nil {
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+		return ""
+	}
+	return fn.Name()
+}
@@ -0,0 +1,79 @@
+package cvaast
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+const cvaSource = `package cva
+
+type Variant[P any, V comparable] struct{}
+
+func (v *Variant[P, V]) Map(classes map[V]string) {}
+
+func Base[P any](classes ...string) {}
+`
+
+const userSource = `package p
+
+import "github.com/Roundaround/cva-go"
+
+func f(v *cva.Variant[int, string]) {
+	cva.Base[int]("a")
+	(cva.Base[int])("b")
+	v.Map(nil)
+	g()
+	_ = len("c")
+}
+
+func g() {}
+`
+
+func TestCalleeName(t *testing.T) {
+	fset := token.NewFileSet()
+	check := func(
+		path, src string,
+		imp types.Importer,
+		info *types.Info,
+	) (*types.Package, *ast.File) {
+		file, err := parser.ParseFile(fset, path+".go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := types.Config{Importer: imp}
+		pkg, err := conf.Check(path, fset, []*ast.File{file}, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkg, file
+	}
+
+	cva, _ := check(Path, cvaSource, importer.Default(), nil)
+	info := &types.Info{Uses: make(map[*ast.Ident]types.Object)}
+	_, file := check("p", userSource, importerFunc(func(path string) (*types.Package, error) {
+		return cva, nil
+	}), info)
+
+	var got []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			got = append(got, CalleeName(info, call.Fun))
+		}
+		return true
+	})
+	want := []string{"Base", "Base", "Variant.Map", "", ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
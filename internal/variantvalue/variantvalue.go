@@ -0,0 +1,56 @@
+// Package variantvalue defines the types of the variant values read with reflection, from struct
+// fields and maps, and how they are parsed from strings. It is shared by struct tags, specs and the
+// code generator, so that they all support the same types.
+package variantvalue
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Supported reports whether values of the given kind can be variant values: bools, strings,
+// integers and floating-point numbers.
+func Supported(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Parse parses s as a value of the given type, which must be of a supported kind. Integers may be
+// written with a base prefix, as with strconv.ParseInt.
+func Parse(typ reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(typ).Elem()
+	var err error
+	switch typ.Kind() {
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		v.SetBool(b)
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 0, typ.Bits())
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(s, 0, typ.Bits())
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, typ.Bits())
+		v.SetFloat(f)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", typ)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid %s value %q", typ, s)
+	}
+	return v, nil
+}
@@ -0,0 +1,57 @@
+package variantvalue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type Size string
+
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		s    string
+		want any
+		err  string
+	}{
+		{name: "bool", typ: reflect.TypeFor[bool](), s: "true", want: true},
+		{name: "named-string", typ: reflect.TypeFor[Size](), s: "small", want: Size("small")},
+		{name: "int", typ: reflect.TypeFor[int8](), s: "-0x10", want: int8(-16)},
+		{name: "uint", typ: reflect.TypeFor[uint16](), s: "65535", want: uint16(65535)},
+		{name: "float", typ: reflect.TypeFor[float32](), s: "1.5", want: float32(1.5)},
+		{
+			name: "overflow",
+			typ:  reflect.TypeFor[uint8](),
+			s:    "256",
+			err:  `invalid uint8 value "256"`,
+		},
+		{
+			name: "unsupported",
+			typ:  reflect.TypeFor[[]string](),
+			s:    "a",
+			err:  "unsupported type []string",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, want := Supported(test.typ.Kind()), test.name != "unsupported"; got != want {
+				t.Errorf("Supported: got %v, want %v", got, want)
+			}
+			got, err := Parse(test.typ, test.s)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Errorf("got error %v, want %s", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Interface() != test.want {
+				t.Errorf("got %v, want %v", got.Interface(), test.want)
+			}
+		})
+	}
+}
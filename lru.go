@@ -0,0 +1,126 @@
+package cva
+
+import "sync"
+
+// lru is a goroutine-safe least-recently-used cache holding up to size entries. A size of zero or
+// less makes the cache unbounded.
+type lru[K comparable, V any] struct {
+	mu     sync.Mutex
+	size   int
+	items  map[K]*lruEntry[K, V]
+	head   *lruEntry[K, V] // most recently used
+	tail   *lruEntry[K, V] // least recently used
+	hits   uint64
+	misses uint64
+}
+
+type lruEntry[K comparable, V any] struct {
+	key        K
+	val        V
+	prev, next *lruEntry[K, V]
+}
+
+func newLRU[K comparable, V any](size int) *lru[K, V] {
+	return &lru[K, V]{size: size, items: make(map[K]*lruEntry[K, V])}
+}
+
+// get returns the value cached for the key, marking it as the most recently used, and records a
+// hit or a miss.
+func (c *lru[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	c.moveToFront(e)
+	return e.val, true
+}
+
+// put caches the value for the key, evicting the least recently used entry if the cache is full.
+func (c *lru[K, V]) put(key K, val V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		e.val = val
+		c.moveToFront(e)
+		return
+	}
+
+	e := &lruEntry[K, V]{key: key, val: val}
+	c.items[key] = e
+	c.pushFront(e)
+	if c.size > 0 && len(c.items) > c.size {
+		oldest := c.tail
+		c.unlink(oldest)
+		delete(c.items, oldest.key)
+	}
+}
+
+// clear removes every entry and resets the statistics.
+func (c *lru[K, V]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.items)
+	c.head, c.tail = nil, nil
+	c.hits, c.misses = 0, 0
+}
+
+// stats returns the cache's statistics.
+func (c *lru[K, V]) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{Hits: c.hits, Misses: c.misses, Len: len(c.items), Size: c.size}
+}
+
+func (c *lru[K, V]) moveToFront(e *lruEntry[K, V]) {
+	if c.head == e {
+		return
+	}
+	c.unlink(e)
+	c.pushFront(e)
+}
+
+func (c *lru[K, V]) pushFront(e *lruEntry[K, V]) {
+	e.prev, e.next = nil, c.head
+	if c.head != nil {
+		c.head.prev = e
+	}
+	c.head = e
+	if c.tail == nil {
+		c.tail = e
+	}
+}
+
+func (c *lru[K, V]) unlink(e *lruEntry[K, V]) {
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		c.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		c.tail = e.prev
+	}
+	e.prev, e.next = nil, nil
+}
+
+// CacheStats reports the usage of a cache, such as the one backing a Memo.
+type CacheStats struct {
+	// Hits is the number of lookups served from the cache.
+	Hits uint64
+	// Misses is the number of lookups that had to compute their result.
+	Misses uint64
+	// Len is the number of entries currently cached.
+	Len int
+	// Size is the maximum number of entries, or zero or less for an unbounded cache.
+	Size int
+}
@@ -0,0 +1,68 @@
+package cva
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLRU(t *testing.T) {
+	keys := func(c *lru[string, int]) []string {
+		var ks []string
+		for e := c.head; e != nil; e = e.next {
+			ks = append(ks, e.key)
+		}
+		return ks
+	}
+
+	t.Run("eviction", func(t *testing.T) {
+		c := newLRU[string, int](2)
+		c.put("a", 1)
+		c.put("b", 2)
+		c.get("a")
+		c.put("c", 3)
+
+		if got, want := keys(c), []string{"c", "a"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if _, ok := c.get("b"); ok {
+			t.Errorf("got b cached, want evicted")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		c := newLRU[string, int](2)
+		c.put("a", 1)
+		c.put("b", 2)
+		c.put("a", 3)
+
+		if got, _ := c.get("a"); got != 3 {
+			t.Errorf("got %d, want %d", got, 3)
+		}
+		if got, want := keys(c), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unbounded", func(t *testing.T) {
+		c := newLRU[string, int](0)
+		for _, k := range []string{"a", "b", "c", "d"} {
+			c.put(k, 0)
+		}
+		if got := c.stats().Len; got != 4 {
+			t.Errorf("got %d entries, want %d", got, 4)
+		}
+	})
+
+	t.Run("single", func(t *testing.T) {
+		c := newLRU[string, int](1)
+		c.put("a", 1)
+		c.put("b", 2)
+
+		if got, want := keys(c), []string{"b"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if c.tail != c.head {
+			t.Errorf("got tail %v, want head %v", c.tail, c.head)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package cva
+
+// Memo is a memoized function, caching the results for the most recently used inputs. It is safe
+// for concurrent use, so it can back the getters of components shared between goroutines, e.g. by
+// concurrent HTTP handlers.
+//
+// The P type parameter is the type of the function's input, K the type of the cache keys derived
+// from it and R the type of the function's result.
+type Memo[P any, K comparable, R any] struct {
+	fn    func(P) R
+	key   func(P) K
+	cache *lru[K, R]
+}
+
+// NewMemo memoizes the given function, caching the results for up to size distinct inputs. Once
+// full, the least recently used result is evicted. A size of zero or less makes the cache
+// unbounded.
+func NewMemo[P comparable, R any](size int, fn func(P) R) *Memo[P, P, R] {
+	return NewMemoFunc(size, func(p P) P { return p }, fn)
+}
+
+// NewMemoFunc is like NewMemo, but caches the results by the keys returned by the key function,
+// allowing inputs that are not comparable (e.g. holding slices or maps) to be memoized. Inputs
+// with the same key must produce the same result.
+func NewMemoFunc[P any, K comparable, R any](
+	size int,
+	key func(P) K,
+	fn func(P) R,
+) *Memo[P, K, R] {
+	return &Memo[P, K, R]{fn: fn, key: key, cache: newLRU[K, R](size)}
+}
+
+// Get returns the function's result for the input, computing it only if it is not cached.
+//
+// Concurrent calls for the same uncached input may each compute the result.
+func (m *Memo[P, K, R]) Get(p P) R {
+	key := m.key(p)
+	if r, ok := m.cache.get(key); ok {
+		return r
+	}
+	r := m.fn(p)
+	m.cache.put(key, r)
+	return r
+}
+
+// Func returns the memoized function, for use wherever a plain function is expected, such as the
+// getter of MapVariant or the mapper of Inherit.
+func (m *Memo[P, K, R]) Func() func(P) R {
+	return m.Get
+}
+
+// Stats returns the memo's cache statistics.
+func (m *Memo[P, K, R]) Stats() CacheStats {
+	return m.cache.stats()
+}
+
+// Clear empties the memo's cache and resets its statistics.
+func (m *Memo[P, K, R]) Clear() {
+	m.cache.clear()
+}
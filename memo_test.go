@@ -0,0 +1,107 @@
+package cva
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMemo(t *testing.T) {
+	t.Run("alternating", func(t *testing.T) {
+		callCount := 0
+		memo := NewMemo(2, func(x int) int {
+			callCount++
+			return x * 2
+		})
+
+		for _, x := range []int{1, 2, 1, 2, 1} {
+			if got := memo.Get(x); got != x*2 {
+				t.Errorf("got %d, want %d", got, x*2)
+			}
+		}
+		if callCount != 2 {
+			t.Errorf("callCount = %d, want %d", callCount, 2)
+		}
+	})
+
+	t.Run("eviction", func(t *testing.T) {
+		callCount := 0
+		memo := NewMemo(2, func(x int) int {
+			callCount++
+			return x
+		})
+
+		for _, x := range []int{1, 2, 3, 1} {
+			memo.Get(x)
+		}
+		if callCount != 4 {
+			t.Errorf("callCount = %d, want %d", callCount, 4)
+		}
+	})
+
+	t.Run("key_func", func(t *testing.T) {
+		type Props struct {
+			Classes []string
+		}
+
+		callCount := 0
+		memo := NewMemoFunc(
+			8,
+			func(p Props) string { return strings.Join(p.Classes, " ") },
+			func(p Props) int {
+				callCount++
+				return len(p.Classes)
+			},
+		)
+		fn := memo.Func()
+
+		fn(Props{Classes: []string{"a", "b"}})
+		if got := fn(Props{Classes: []string{"a", "b"}}); got != 2 {
+			t.Errorf("got %d, want %d", got, 2)
+		}
+		if callCount != 1 {
+			t.Errorf("callCount = %d, want %d", callCount, 1)
+		}
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		memo := NewMemo(4, func(x int) int { return x })
+		for _, x := range []int{1, 1, 2, 1, 3} {
+			memo.Get(x)
+		}
+
+		want := CacheStats{Hits: 2, Misses: 3, Len: 3, Size: 4}
+		if got := memo.Stats(); got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+
+		memo.Clear()
+		want = CacheStats{Size: 4}
+		if got := memo.Stats(); got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		memo := NewMemo(3, func(x int) int { return x * 2 })
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 100 {
+					x := (i + j) % 5
+					if got := memo.Get(x); got != x*2 {
+						t.Errorf("got %d, want %d", got, x*2)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := memo.Stats().Len; got > 3 {
+			t.Errorf("got %d entries, want at most %d", got, 3)
+		}
+	})
+}
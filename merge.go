@@ -0,0 +1,65 @@
+package cva
+
+import (
+	"sync/atomic"
+)
+
+// Merger post-processes the joined class list of a component, e.g. to resolve conflicting or
+// duplicated classes.
+type Merger interface {
+	Merge(classes ...string) string
+}
+
+// MergerFunc adapts a function to the Merger interface. Both DedupeClasses and twmerge.Merge from
+// github.com/Oudwins/tailwind-merge-go can be used directly, e.g. cva.MergerFunc(twmerge.Merge).
+type MergerFunc func(classes ...string) string
+
+// Merge calls f(classes...).
+func (f MergerFunc) Merge(classes ...string) string {
+	return f(classes...)
+}
+
+// Dedupe is a Merger that removes duplicate classes using DedupeClasses.
+var Dedupe Merger = MergerFunc(DedupeClasses)
+
+type mergerBox struct {
+	merger Merger
+}
+
+var defaultMerger atomic.Pointer[mergerBox]
+
+// SetDefaultMerger sets the Merger used by every component that does not configure its own with
+// WithMerger (directly or through Inherit). Passing nil removes the default merger.
+//
+// The default merger is looked up each time classes are generated, so it can be set after
+// components have been defined, typically during program initialization.
+func SetDefaultMerger(m Merger) {
+	if m == nil {
+		defaultMerger.Store(nil)
+		return
+	}
+	defaultMerger.Store(&mergerBox{m})
+}
+
+// WithMerger sets the Merger used to post-process the component's class lists. Passing nil
+// disables merging for the component, even when a default merger is set with SetDefaultMerger.
+//
+// Components created with Inherit use the base component's merger unless they configure their
+// own.
+func WithMerger[P any](m Merger) Option[P] {
+	return func(c *Cva[P]) {
+		c.merger = m
+		c.hasMerger = true
+	}
+}
+
+// activeMerger returns the merger to use for the component, or nil if none.
+func (c *Cva[P]) activeMerger() Merger {
+	if c.hasMerger {
+		return c.merger
+	}
+	if box := defaultMerger.Load(); box != nil {
+		return box.merger
+	}
+	return nil
+}
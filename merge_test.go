@@ -0,0 +1,146 @@
+package cva
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMerger(t *testing.T) {
+	type Props struct {
+		Size string
+	}
+
+	// lastPadding is a toy merger keeping only the last "px-*" class.
+	lastPadding := MergerFunc(func(classes ...string) string {
+		fields := strings.Fields(strings.Join(classes, " "))
+		kept := make([]string, 0, len(fields))
+		last := -1
+		for _, f := range fields {
+			if strings.HasPrefix(f, "px-") {
+				if last >= 0 {
+					kept = append(kept[:last], kept[last+1:]...)
+				}
+				last = len(kept)
+			}
+			kept = append(kept, f)
+		}
+		return strings.Join(kept, " ")
+	})
+
+	options := []Option[Props]{
+		Base[Props]("button px-4 rounded"),
+		MapVariant(
+			func(p Props) string { return p.Size },
+			map[string]string{"small": "px-2 rounded", "large": "px-6"},
+		),
+	}
+
+	t.Run("none", func(t *testing.T) {
+		button := New(options...)
+
+		got := button.Classes(Props{Size: "small"})
+		want := "button px-4 rounded px-2 rounded"
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("dedupe", func(t *testing.T) {
+		button := New(append(options, WithMerger[Props](Dedupe))...)
+
+		got := button.Classes(Props{Size: "small"})
+		want := "button px-4 rounded px-2"
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		button := New(append(options, WithMerger[Props](lastPadding))...)
+
+		got := button.Classes(Props{Size: "large"})
+		want := "button rounded px-6"
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("slots", func(t *testing.T) {
+		card := New(
+			WithMerger[Props](Dedupe),
+			Base[Props]("card card"),
+			InSlot("header", Base[Props]("header header")),
+		)
+
+		got := card.Slots(Props{})
+		if got.Get("") != "card" {
+			t.Errorf("root: got %s, want %s", got.Get(""), "card")
+		}
+		if got.Get("header") != "header" {
+			t.Errorf("header: got %s, want %s", got.Get("header"), "header")
+		}
+	})
+
+	t.Run("inherit", func(t *testing.T) {
+		base := New(append(options, WithMerger[Props](Dedupe))...)
+
+		inherited := New(
+			Inherit(base, func(p Props) Props { return p }),
+			Static[Props]("button"),
+		)
+		overridden := New(
+			Inherit(base, func(p Props) Props { return p }),
+			WithMerger[Props](lastPadding),
+		)
+		disabled := New(
+			WithMerger[Props](nil),
+			Inherit(base, func(p Props) Props { return p }),
+		)
+
+		tests := []struct {
+			name string
+			cva  *Cva[Props]
+			want string
+		}{
+			{
+				name: "inherited",
+				cva:  inherited,
+				want: "button px-4 rounded px-2",
+			},
+			{
+				name: "overridden",
+				cva:  overridden,
+				want: "button rounded px-2 rounded",
+			},
+			{
+				name: "disabled",
+				cva:  disabled,
+				want: "button px-4 rounded px-2 rounded",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := test.cva.Classes(Props{Size: "small"})
+				if got != test.want {
+					t.Errorf("got %s, want %s", got, test.want)
+				}
+			})
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		SetDefaultMerger(Dedupe)
+		defer SetDefaultMerger(nil)
+
+		button := New(options...)
+		disabled := New(append(options, WithMerger[Props](nil))...)
+
+		if got, want := button.Classes(Props{Size: "small"}), "button px-4 rounded px-2"; got != want {
+			t.Errorf("default: got %s, want %s", got, want)
+		}
+		if got, want := disabled.Classes(Props{Size: "small"}), "button px-4 rounded px-2 rounded"; got != want {
+			t.Errorf("disabled: got %s, want %s", got, want)
+		}
+	})
+}
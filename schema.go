@@ -0,0 +1,489 @@
+package cva
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// Kind identifies the type of option described by an OptionSchema.
+type Kind int
+
+const (
+	// KindClasses is an option created with Classes, whose class lists are computed dynamically.
+	KindClasses Kind = iota
+	// KindBase is an option created with Base or Static.
+	KindBase
+	// KindMap is an option created with MapVariant or Variant.Map.
+	KindMap
+	// KindCompound is an option created with CompoundVariant or one of its n-ary counterparts.
+	KindCompound
+	// KindPredicate is an option created with PredicateVariant, Matcher.Then or When.
+	KindPredicate
+	// KindInherit is an option created with Inherit.
+	KindInherit
+)
+
+// String returns a human-readable name for the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindClasses:
+		return "classes"
+	case KindBase:
+		return "base"
+	case KindMap:
+		return "map"
+	case KindCompound:
+		return "compound"
+	case KindPredicate:
+		return "predicate"
+	case KindInherit:
+		return "inherit"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Schema describes the definition of a component, as returned by Cva.Schema. It is intended for
+// building tooling such as documentation, component galleries and linters.
+type Schema struct {
+	// Name is the component's name, as set with the Name option.
+	Name string
+	// Slots lists the component's named slots, in the order they were first targeted.
+	Slots []string
+	// Options describes each of the component's options, in the order they were applied.
+	Options []OptionSchema
+	// Defaults describes each of the component's DefaultVariant and DefaultVariants options, in the
+	// order they were applied. Defaults set with Variant.WithDefault are reported by the variants'
+	// schemas instead.
+	Defaults []DefaultSchema
+}
+
+// DefaultSchema describes a single option created with DefaultVariant or DefaultVariants. The
+// default values it sets are computed by an opaque function, so only its location is known.
+type DefaultSchema struct {
+	// Slots lists the slots the defaults apply to when the option is targeted at slots with InSlot
+	// or InSlots, and is empty for defaults applying to the whole component.
+	Slots []string
+	// File and Line locate the code that created the option, if known.
+	File string
+	Line int
+}
+
+// OptionSchema describes a single option of a component.
+type OptionSchema struct {
+	// Kind is the type of the option.
+	Kind Kind
+	// Label is the option's label, as set with the Label option.
+	Label string
+	// Slots lists the named slots the option targets. It is empty for options targeting the root
+	// element.
+	Slots []string
+	// Variants describes the variants the option depends on. For compound options, there is one
+	// entry per position of the compound tuple.
+	Variants []VariantSchema
+	// Classes is the class list applied by base and predicate options.
+	Classes []string
+	// Cases lists the value to class list mappings of map and compound options.
+	Cases []CaseSchema
+	// Condition describes the logic of predicate options.
+	Condition *Condition
+	// Inherited is the schema of the base component of inherit options.
+	Inherited *Schema
+	// Exhaustive reports whether the option must cover every value of its variants, as required
+	// with the Exhaustive option.
+	Exhaustive bool
+	// File and Line locate the code that created the option, if known.
+	File string
+	Line int
+}
+
+// VariantSchema describes a single variant.
+type VariantSchema struct {
+	// Name is the variant's name, as set with Variant.WithName or the Label option.
+	Name string
+	// Values lists the variant's known values.
+	Values []any
+	// Default is the variant's default value, and is only meaningful if HasDefault is true.
+	Default any
+	// HasDefault reports whether the variant has a default value.
+	HasDefault bool
+
+	id uint64
+	// typ is the type of the variant's values, if known.
+	typ reflect.Type
+	// declared reports whether Values is the variant's complete domain, as declared with
+	// Variant.WithValues or Exhaustive, rather than derived from the option's cases.
+	declared bool
+}
+
+// CaseSchema describes a single entry of a map or compound option.
+type CaseSchema struct {
+	// Values lists the matched values for each of the option's variants. A nil entry matches any
+	// value.
+	Values [][]any
+	// Classes is the class list applied when the case matches.
+	Classes []string
+}
+
+// Op identifies the type of a Condition.
+type Op int
+
+const (
+	// OpFunc is an opaque predicate function, such as one passed to PredicateVariant.
+	OpFunc Op = iota
+	// OpIs matches when the variant is equal to the single value in Values.
+	OpIs
+	// OpIn matches when the variant is equal to any of the values in Values.
+	OpIn
+	// OpTest matches when the variant passes an opaque test function.
+	OpTest
+	// OpNot matches when its single operand does not match.
+	OpNot
+	// OpAnd matches when all of its operands match.
+	OpAnd
+	// OpOr matches when any of its operands match.
+	OpOr
+)
+
+// Condition describes the logic of a Matcher.
+type Condition struct {
+	// Op is the type of the condition.
+	Op Op
+	// Variant is the variant tested by OpIs, OpIn and OpTest conditions.
+	Variant VariantSchema
+	// Values lists the values matched by OpIs and OpIn conditions.
+	Values []any
+	// Operands lists the nested conditions of OpNot, OpAnd and OpOr conditions.
+	Operands []Condition
+}
+
+// String returns a human-readable description of the condition, e.g.
+// `size == "large" && !(style in ("icon", "link"))`.
+func (c Condition) String() string {
+	variantName := func() string {
+		if c.Variant.Name == "" {
+			return "variant"
+		}
+		return c.Variant.Name
+	}
+
+	switch c.Op {
+	case OpIs:
+		return variantName() + " == " + formatValue(c.Values[0])
+	case OpIn:
+		vals := make([]string, len(c.Values))
+		for i, v := range c.Values {
+			vals[i] = formatValue(v)
+		}
+		return variantName() + " in (" + strings.Join(vals, ", ") + ")"
+	case OpTest:
+		return "test(" + variantName() + ")"
+	case OpNot:
+		return "!(" + c.Operands[0].String() + ")"
+	case OpAnd, OpOr:
+		sep := " && "
+		if c.Op == OpOr {
+			sep = " || "
+		}
+		parts := make([]string, len(c.Operands))
+		for i, operand := range c.Operands {
+			parts[i] = operand.String()
+			if (operand.Op == OpAnd || operand.Op == OpOr) && operand.Op != c.Op {
+				parts[i] = "(" + parts[i] + ")"
+			}
+		}
+		return strings.Join(parts, sep)
+	}
+	return "func(props)"
+}
+
+// variants returns the unique variants referenced by the condition, in the order they are first
+// referenced.
+func (c Condition) variants() []VariantSchema {
+	var vs []VariantSchema
+	var walk func(Condition)
+	walk = func(c Condition) {
+		switch c.Op {
+		case OpIs, OpIn, OpTest:
+			if !slices.ContainsFunc(vs, func(v VariantSchema) bool { return v.id == c.Variant.id }) {
+				vs = append(vs, c.Variant)
+			}
+		}
+		for _, operand := range c.Operands {
+			walk(operand)
+		}
+	}
+	walk(c)
+	return vs
+}
+
+// Schema returns a description of the component's definition. The returned schema is a copy,
+// which can be modified without affecting the component.
+//
+// Options inherited with Inherit are reported as a single inherit option, with the base
+// component's schema nested within it.
+func (c *Cva[P]) Schema() Schema {
+	s := Schema{
+		Name:     c.name,
+		Slots:    slices.Clone(c.slots),
+		Defaults: cloneDefaults(c.defaultInfo),
+	}
+	seen := make(map[*OptionSchema]bool)
+	for _, p := range c.producers {
+		if !seen[p.info] {
+			seen[p.info] = true
+			s.Options = append(s.Options, p.info.clone())
+		}
+	}
+	return s
+}
+
+// clone returns a deep copy of the schema, so that modifying it does not affect the component it
+// describes.
+func (s Schema) clone() Schema {
+	s.Slots = slices.Clone(s.Slots)
+	s.Defaults = cloneDefaults(s.Defaults)
+	options := make([]OptionSchema, len(s.Options))
+	for i, opt := range s.Options {
+		options[i] = opt.clone()
+	}
+	s.Options = options
+	return s
+}
+
+// cloneDefaults returns a deep copy of the defaults' schemas.
+func cloneDefaults(defaults []DefaultSchema) []DefaultSchema {
+	if defaults == nil {
+		return nil
+	}
+	clones := make([]DefaultSchema, len(defaults))
+	for i, d := range defaults {
+		d.Slots = slices.Clone(d.Slots)
+		clones[i] = d
+	}
+	return clones
+}
+
+// clone returns a deep copy of the option's schema.
+func (o OptionSchema) clone() OptionSchema {
+	o.Slots = slices.Clone(o.Slots)
+	o.Classes = slices.Clone(o.Classes)
+	if o.Variants != nil {
+		variants := make([]VariantSchema, len(o.Variants))
+		for i, v := range o.Variants {
+			variants[i] = v.clone()
+		}
+		o.Variants = variants
+	}
+	if o.Cases != nil {
+		cases := make([]CaseSchema, len(o.Cases))
+		for i, c := range o.Cases {
+			cases[i] = CaseSchema{
+				Values:  make([][]any, len(c.Values)),
+				Classes: slices.Clone(c.Classes),
+			}
+			for j, vals := range c.Values {
+				cases[i].Values[j] = slices.Clone(vals)
+			}
+		}
+		o.Cases = cases
+	}
+	if o.Condition != nil {
+		cond := o.Condition.clone()
+		o.Condition = &cond
+	}
+	if o.Inherited != nil {
+		inherited := o.Inherited.clone()
+		o.Inherited = &inherited
+	}
+	return o
+}
+
+// clone returns a deep copy of the variant's schema.
+func (v VariantSchema) clone() VariantSchema {
+	v.Values = slices.Clone(v.Values)
+	return v
+}
+
+// clone returns a deep copy of the condition.
+func (c Condition) clone() Condition {
+	c.Variant = c.Variant.clone()
+	c.Values = slices.Clone(c.Values)
+	if c.Operands != nil {
+		operands := make([]Condition, len(c.Operands))
+		for i, operand := range c.Operands {
+			operands[i] = operand.clone()
+		}
+		c.Operands = operands
+	}
+	return c
+}
+
+// Name sets the component's name, used to identify it in its Schema and in tooling built on top
+// of it.
+func Name[P any](name string) Option[P] {
+	return func(c *Cva[P]) {
+		c.name = name
+	}
+}
+
+// Label labels the given options, identifying them in the component's Schema. For map options, the
+// label is also used as the variant's name if it does not already have one.
+func Label[P any](label string, opts ...Option[P]) Option[P] {
+	return func(c *Cva[P]) {
+		inner := New(opts...)
+		c.absorb(inner)
+		for _, p := range inner.producers {
+			p.info.Label = label
+			if p.info.Kind == KindMap && p.info.Variants[0].Name == "" {
+				p.info.Variants = slices.Clone(p.info.Variants)
+				p.info.Variants[0].Name = label
+			}
+			c.addProducer(p)
+		}
+	}
+}
+
+// NameVariants names the unnamed variants of the given map and compound options, in order: the
+// single variant of map options, or each position of compound options. Names identify the
+// variants in the component's Schema and in tooling built on top of it.
+func NameVariants[P any](names []string, opts ...Option[P]) Option[P] {
+	return func(c *Cva[P]) {
+		inner := New(opts...)
+		c.absorb(inner)
+		for _, p := range inner.producers {
+			if p.info.Kind == KindMap || p.info.Kind == KindCompound {
+				p.info.Variants = slices.Clone(p.info.Variants)
+				for i, name := range names {
+					if i < len(p.info.Variants) && p.info.Variants[i].Name == "" {
+						p.info.Variants[i].Name = name
+					}
+				}
+			}
+			c.addProducer(p)
+		}
+	}
+}
+
+var variantIDs atomic.Uint64
+
+// nextVariantID returns a unique identifier for a variant, used to recognise the same variant
+// across the options derived from it.
+func nextVariantID() uint64 {
+	return variantIDs.Add(1)
+}
+
+// describeAs returns a function describing an option with a fixed schema, for use with newOption.
+func describeAs(schema OptionSchema) func() OptionSchema {
+	return func() OptionSchema { return schema }
+}
+
+// toAny converts a list of values to a list of empty interfaces.
+func toAny[V any](vals []V) []any {
+	if vals == nil {
+		return nil
+	}
+	anys := make([]any, len(vals))
+	for i, v := range vals {
+		anys[i] = v
+	}
+	return anys
+}
+
+// sortedKeys returns the keys of the map in a deterministic order.
+func sortedKeys[V comparable, S any](m map[V]S) []V {
+	keys := make([]V, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b V) int {
+		return compareValues(a, b)
+	})
+	return keys
+}
+
+// compareValues orders variant values of the same type, numerically for numbers, lexically for
+// strings and by their formatted representation otherwise.
+func compareValues(a, b any) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.IsValid() && vb.IsValid() && va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(va.Int(), vb.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr:
+			return cmp.Compare(va.Uint(), vb.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(va.Float(), vb.Float())
+		case reflect.String:
+			return cmp.Compare(va.String(), vb.String())
+		case reflect.Bool:
+			return cmp.Compare(boolInt(va.Bool()), boolInt(vb.Bool()))
+		}
+	}
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// formatValue formats a variant value for display, quoting strings.
+func formatValue(v any) string {
+	if rv := reflect.ValueOf(v); rv.IsValid() && rv.Kind() == reflect.String {
+		return fmt.Sprintf("%q", rv.String())
+	}
+	return fmt.Sprint(v)
+}
+
+// AllClasses returns every class the component can ever emit, sorted and deduplicated. See
+// Schema.AllClasses for details.
+func (c *Cva[P]) AllClasses() []string {
+	return c.Schema().AllClasses()
+}
+
+// AllClasses returns every class token listed in the schema, sorted and deduplicated. This
+// includes the class lists of all base, map, compound and predicate options, recursively through
+// inherited components.
+//
+// Classes computed dynamically by options created with Classes cannot be known ahead of time and
+// are not included.
+//
+// The result is suitable for generating a TailwindCSS safelist, making sure every class used by
+// the component is generated even when the content scanner cannot find it.
+func (s Schema) AllClasses() []string {
+	seen := make(map[string]struct{})
+	var add func(s Schema)
+	add = func(s Schema) {
+		for _, opt := range s.Options {
+			lists := [][]string{opt.Classes}
+			for _, c := range opt.Cases {
+				lists = append(lists, c.Classes)
+			}
+			for _, list := range lists {
+				for _, classes := range list {
+					for _, class := range strings.Fields(classes) {
+						seen[class] = struct{}{}
+					}
+				}
+			}
+			if opt.Inherited != nil {
+				add(*opt.Inherited)
+			}
+		}
+	}
+	add(s)
+
+	classes := make([]string, 0, len(seen))
+	for class := range seen {
+		classes = append(classes, class)
+	}
+	slices.Sort(classes)
+	return classes
+}
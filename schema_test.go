@@ -0,0 +1,369 @@
+package cva
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchema(t *testing.T) {
+	type Props struct {
+		Size     string
+		Style    string
+		Disabled bool
+	}
+
+	size := NewVariant(func(p Props) string { return p.Size }).
+		WithName("size").
+		WithValues("small", "medium", "large").
+		WithDefault("medium")
+	style := NewVariant(func(p Props) string { return p.Style }).WithName("style")
+
+	base := New(
+		Name[Props]("base"),
+		Base[Props]("base"),
+	)
+
+	button := New(
+		Name[Props]("button"),
+		Inherit(base, func(p Props) Props { return p }),
+		Base[Props]("button", "inline-flex"),
+		Label("style", MapVariant(
+			func(p Props) string { return p.Style },
+			map[string]string{"primary": "bg-blue-500", "link": "underline"},
+		)),
+		size.Map(map[string]string{"small": "h-8", "large": "h-12"}),
+		CompoundVariant(
+			func(p Props) (string, bool) { return p.Size, p.Disabled },
+			NewCompound("small", true, "opacity-75"),
+			NewCompoundOf(OneOf("medium", "large"), AnyValue[bool](), "font-bold"),
+		),
+		size.Is("large").And(style.In("primary", "link").Not()).Then("shadow"),
+		PredicateVariant(func(p Props) bool { return p.Disabled }, "opacity-50"),
+		InSlot("icon", Base[Props]("size-4")),
+		Classes(func(p Props) string { return p.Style }),
+	)
+
+	schema := button.Schema()
+
+	if schema.Name != "button" {
+		t.Errorf("Name: got %q, want %q", schema.Name, "button")
+	}
+	if !reflect.DeepEqual(schema.Slots, []string{"icon"}) {
+		t.Errorf("Slots: got %v, want %v", schema.Slots, []string{"icon"})
+	}
+
+	wantKinds := []Kind{
+		KindInherit,
+		KindBase,
+		KindMap,
+		KindMap,
+		KindCompound,
+		KindPredicate,
+		KindPredicate,
+		KindBase,
+		KindClasses,
+	}
+	if len(schema.Options) != len(wantKinds) {
+		t.Fatalf("got %d options, want %d", len(schema.Options), len(wantKinds))
+	}
+	for i, want := range wantKinds {
+		if got := schema.Options[i].Kind; got != want {
+			t.Errorf("option %d: got kind %s, want %s", i, got, want)
+		}
+	}
+
+	t.Run("inherit", func(t *testing.T) {
+		inherited := schema.Options[0].Inherited
+		if inherited == nil {
+			t.Fatal("got nil inherited schema")
+		}
+		if inherited.Name != "base" {
+			t.Errorf("got name %q, want %q", inherited.Name, "base")
+		}
+		if len(inherited.Options) != 1 || inherited.Options[0].Kind != KindBase {
+			t.Errorf("got inherited options %+v, want a single base option", inherited.Options)
+		}
+	})
+
+	t.Run("base", func(t *testing.T) {
+		got := schema.Options[1].Classes
+		want := []string{"button", "inline-flex"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("map_variant", func(t *testing.T) {
+		opt := schema.Options[2]
+		if opt.Label != "style" {
+			t.Errorf("Label: got %q, want %q", opt.Label, "style")
+		}
+		if opt.Variants[0].Name != "style" {
+			t.Errorf("variant name: got %q, want %q", opt.Variants[0].Name, "style")
+		}
+		wantValues := []any{"link", "primary"}
+		if !reflect.DeepEqual(opt.Variants[0].Values, wantValues) {
+			t.Errorf("values: got %v, want %v", opt.Variants[0].Values, wantValues)
+		}
+		wantCases := []CaseSchema{
+			{Values: [][]any{{"link"}}, Classes: []string{"underline"}},
+			{Values: [][]any{{"primary"}}, Classes: []string{"bg-blue-500"}},
+		}
+		if !reflect.DeepEqual(opt.Cases, wantCases) {
+			t.Errorf("cases: got %v, want %v", opt.Cases, wantCases)
+		}
+	})
+
+	t.Run("variant_map", func(t *testing.T) {
+		v := schema.Options[3].Variants[0]
+		if v.Name != "size" {
+			t.Errorf("name: got %q, want %q", v.Name, "size")
+		}
+		wantValues := []any{"small", "medium", "large"}
+		if !reflect.DeepEqual(v.Values, wantValues) {
+			t.Errorf("values: got %v, want %v", v.Values, wantValues)
+		}
+		if !v.HasDefault || v.Default != "medium" {
+			t.Errorf("default: got %v (%v), want %v", v.Default, v.HasDefault, "medium")
+		}
+	})
+
+	t.Run("compound", func(t *testing.T) {
+		opt := schema.Options[4]
+		if len(opt.Variants) != 2 {
+			t.Fatalf("got %d variants, want 2", len(opt.Variants))
+		}
+		if want := []any{"small", "medium", "large"}; !reflect.DeepEqual(opt.Variants[0].Values, want) {
+			t.Errorf("position 0 values: got %v, want %v", opt.Variants[0].Values, want)
+		}
+		if want := []any{true}; !reflect.DeepEqual(opt.Variants[1].Values, want) {
+			t.Errorf("position 1 values: got %v, want %v", opt.Variants[1].Values, want)
+		}
+		wantCases := []CaseSchema{
+			{Values: [][]any{{"small"}, {true}}, Classes: []string{"opacity-75"}},
+			{Values: [][]any{{"medium", "large"}, nil}, Classes: []string{"font-bold"}},
+		}
+		if !reflect.DeepEqual(opt.Cases, wantCases) {
+			t.Errorf("cases: got %v, want %v", opt.Cases, wantCases)
+		}
+	})
+
+	t.Run("matcher", func(t *testing.T) {
+		opt := schema.Options[5]
+		if opt.Condition == nil {
+			t.Fatal("got nil condition")
+		}
+		wantCond := `size == "large" && !(style in ("primary", "link"))`
+		if got := opt.Condition.String(); got != wantCond {
+			t.Errorf("condition: got %s, want %s", got, wantCond)
+		}
+		if len(opt.Variants) != 2 || opt.Variants[0].Name != "size" || opt.Variants[1].Name != "style" {
+			t.Errorf("got variants %+v, want size and style", opt.Variants)
+		}
+		if want := []string{"shadow"}; !reflect.DeepEqual(opt.Classes, want) {
+			t.Errorf("classes: got %v, want %v", opt.Classes, want)
+		}
+	})
+
+	t.Run("predicate", func(t *testing.T) {
+		opt := schema.Options[6]
+		if opt.Condition == nil || opt.Condition.Op != OpFunc {
+			t.Errorf("got condition %v, want an opaque function", opt.Condition)
+		}
+		if len(opt.Variants) != 0 {
+			t.Errorf("got variants %+v, want none", opt.Variants)
+		}
+	})
+
+	t.Run("slot", func(t *testing.T) {
+		if got := schema.Options[7].Slots; !reflect.DeepEqual(got, []string{"icon"}) {
+			t.Errorf("got %v, want %v", got, []string{"icon"})
+		}
+	})
+
+	t.Run("reused_option", func(t *testing.T) {
+		opt := MapVariant(func(p Props) string { return p.Size }, map[string]string{"small": "sm"})
+
+		labelled := New(Label("size", opt))
+		plain := New(opt)
+
+		if got := labelled.Schema().Options[0].Variants[0].Name; got != "size" {
+			t.Errorf("labelled: got %q, want %q", got, "size")
+		}
+		if got := plain.Schema().Options[0].Variants[0].Name; got != "" {
+			t.Errorf("plain: got %q, want empty name", got)
+		}
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		classes := []string{"a", "b"}
+		copied := New(
+			Base[Props](classes...),
+			size.Map(map[string]string{"small": "h-8"}),
+			size.Is("large").And(style.Is("link")).Then(classes...),
+			Inherit(New(Base[Props]("c")), func(p Props) Props { return p }),
+		)
+		classes[0] = "CALLER"
+
+		s := copied.Schema()
+		s.Options[0].Classes[0] = "MUTATED"
+		s.Options[1].Variants[0].Values[0] = "MUTATED"
+		s.Options[1].Cases[0].Values[0][0] = "MUTATED"
+		s.Options[1].Cases[0].Classes[0] = "MUTATED"
+		s.Options[2].Condition.Operands[0].Values[0] = "MUTATED"
+		s.Options[3].Inherited.Options[0].Classes[0] = "MUTATED"
+
+		got := strings.Join(copied.AllClasses(), " ")
+		if want := "a b c h-8"; got != want {
+			t.Errorf("AllClasses: got %s, want %s", got, want)
+		}
+		cond := copied.Schema().Options[2].Condition.String()
+		if want := `size == "large" && style == "link"`; cond != want {
+			t.Errorf("condition: got %s, want %s", cond, want)
+		}
+		if got, want := copied.Classes(Props{Size: "large", Style: "link"}), "a b a b c"; got != want {
+			t.Errorf("Classes: got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		if len(schema.Defaults) != 0 {
+			t.Errorf("got defaults %+v, want none", schema.Defaults)
+		}
+
+		defaulted := New(
+			DefaultVariant(func(p *Props) *string { return &p.Size }, "medium"),
+			Label("style", DefaultVariants(func(p Props) Props { return p })),
+		)
+		defaults := defaulted.Schema().Defaults
+		if len(defaults) != 2 {
+			t.Fatalf("got %d defaults, want 2", len(defaults))
+		}
+		for i, d := range defaults {
+			if !strings.HasSuffix(d.File, "schema_test.go") || d.Line == 0 {
+				t.Errorf("default %d: got location %s:%d, want schema_test.go", i, d.File, d.Line)
+			}
+		}
+	})
+
+	t.Run("named_variants", func(t *testing.T) {
+		named := New(NameVariants([]string{"size", "disabled"},
+			size.Map(map[string]string{"small": "h-8"}),
+			CompoundVariant(
+				func(p Props) (string, bool) { return p.Size, p.Disabled },
+				NewCompound("small", true, "opacity-75"),
+			),
+		))
+
+		options := named.Schema().Options
+		if got := options[0].Variants[0].Name; got != "size" {
+			t.Errorf("map: got %q, want %q", got, "size")
+		}
+		got := []string{options[1].Variants[0].Name, options[1].Variants[1].Name}
+		if want := []string{"size", "disabled"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("compound: got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestCondition(t *testing.T) {
+	type Props struct {
+		Size  int
+		Theme string
+	}
+
+	size := NewVariant(func(p Props) int { return p.Size }).WithName("size")
+	theme := NewVariant(func(p Props) string { return p.Theme })
+
+	tests := []struct {
+		name    string
+		matcher Matcher[Props]
+		want    string
+	}{
+		{
+			name:    "is",
+			matcher: size.Is(1),
+			want:    "size == 1",
+		},
+		{
+			name:    "unnamed",
+			matcher: theme.IsNot("dark"),
+			want:    `!(variant == "dark")`,
+		},
+		{
+			name:    "test",
+			matcher: size.Test(func(v int) bool { return v > 1 }),
+			want:    "test(size)",
+		},
+		{
+			name:    "nested",
+			matcher: Any(size.Is(1).And(size.NotIn(2, 3)), size.Is(4)),
+			want:    "(size == 1 && !(size in (2, 3))) || size == 4",
+		},
+		{
+			name:    "all",
+			matcher: All(size.Is(1), Matcher[Props]{fn: func(Props) bool { return true }}),
+			want:    "size == 1 && func(props)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.matcher.Condition().String()
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAllClasses(t *testing.T) {
+	type Props struct {
+		Size    string
+		Loading bool
+		Custom  string
+	}
+
+	size := NewVariant(func(p Props) string { return p.Size })
+
+	base := New(
+		Static[Props]("btn  inline-flex"),
+		size.Map(map[string]string{"small": "h-8 px-2", "large": "h-12 px-6"}),
+	)
+
+	button := New(
+		Inherit(base, func(p Props) Props { return p }),
+		MapVariant(
+			func(p Props) bool { return p.Loading },
+			map[bool][]string{true: {"opacity-50", "cursor-wait"}},
+		),
+		CompoundVariant(
+			func(p Props) (string, bool) { return p.Size, p.Loading },
+			NewCompound("small", true, "animate-pulse btn"),
+		),
+		size.Is("large").Then("text-lg"),
+		When(size.Is("small"), "text-sm"),
+		InSlot("icon", Base[Props]("size-4")),
+		Classes(func(p Props) string { return p.Custom }),
+	)
+
+	got := button.AllClasses()
+	want := []string{
+		"animate-pulse",
+		"btn",
+		"cursor-wait",
+		"h-12",
+		"h-8",
+		"inline-flex",
+		"opacity-50",
+		"px-2",
+		"px-6",
+		"size-4",
+		"text-lg",
+		"text-sm",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Roundaround/cva-go/internal/variantvalue"
+)
+
+// binder binds the variants of a spec to the props of type P.
+type binder[P any] struct {
+	typ reflect.Type
+	// fields lists the exported fields variants can read, for struct props.
+	fields []reflect.StructField
+}
+
+// binding reads a variant's value from the props and parses the spec's values of the variant.
+type binding[P any] struct {
+	get   func(P) any
+	parse func(string) (any, error)
+}
+
+// newBinder returns a binder for the props type P, which must be map[string]string or a struct
+// type.
+func newBinder[P any]() (*binder[P], error) {
+	typ := reflect.TypeFor[P]()
+	if typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String &&
+		typ.Elem().Kind() == reflect.String {
+		return &binder[P]{typ: typ}, nil
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("spec: props type %s is neither map[string]string nor a struct", typ)
+	}
+
+	b := &binder[P]{typ: typ}
+	for _, field := range reflect.VisibleFields(typ) {
+		if field.IsExported() && !field.Anonymous {
+			b.fields = append(b.fields, field)
+		}
+	}
+	return b, nil
+}
+
+// bind returns the binding of the named variant, or an error if the props have no field it can
+// read.
+func (b *binder[P]) bind(name string) (binding[P], error) {
+	if b.typ.Kind() == reflect.Map {
+		return binding[P]{
+			get: func(p P) any {
+				return valueOf(reflect.ValueOf(p).MapIndex(reflect.ValueOf(name)))
+			},
+			parse: func(s string) (any, error) {
+				return parseValue(b.typ.Elem(), s)
+			},
+		}, nil
+	}
+
+	field, ok := b.field(name)
+	if !ok {
+		return binding[P]{}, fmt.Errorf("no field of %s for variant %q", b.typ, name)
+	}
+	if !variantvalue.Supported(field.Type.Kind()) {
+		return binding[P]{}, fmt.Errorf(
+			"field %s of %s has unsupported type %s", field.Name, b.typ, field.Type,
+		)
+	}
+	index := field.Index
+	return binding[P]{
+		get: func(p P) any {
+			// Fields promoted through a nil embedded pointer read as missing.
+			v, err := reflect.ValueOf(p).FieldByIndexErr(index)
+			if err != nil {
+				return nil
+			}
+			return valueOf(v)
+		},
+		parse: func(s string) (any, error) {
+			return parseValue(field.Type, s)
+		},
+	}, nil
+}
+
+// field returns the field read by the named variant: the field whose cva or json tag name matches
+// the name, or else the field whose name matches it case-insensitively.
+func (b *binder[P]) field(name string) (reflect.StructField, bool) {
+	for _, field := range b.fields {
+		for _, key := range []string{"cva", "json"} {
+			tagName, _, _ := strings.Cut(field.Tag.Get(key), ",")
+			if tagName != "" && tagName == name {
+				return field, true
+			}
+		}
+	}
+	for _, field := range b.fields {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// valueOf returns the variant value held by v. Missing and zero values are returned as nil, the
+// zero value of the variants' value type, so that defaults apply to them.
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() || v.IsZero() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// parseValue parses s as a value of the given type.
+func parseValue(typ reflect.Type, s string) (any, error) {
+	v, err := variantvalue.Parse(typ, s)
+	if err != nil {
+		return nil, err
+	}
+	return valueOf(v), nil
+}
@@ -0,0 +1,308 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Roundaround/cva-go"
+)
+
+// definition is a component definition read from a spec, with its values kept as written.
+type definition struct {
+	name      string
+	base      string
+	hasBase   bool
+	variants  []*variantDef
+	defaults  []valueRef
+	compounds []compoundDef
+}
+
+// variantDef is a single variant of a spec.
+type variantDef struct {
+	name  string
+	key   *node
+	path  string
+	cases []caseDef
+}
+
+// caseDef is a single value to class list mapping of a variant.
+type caseDef struct {
+	value   valueRef
+	classes string
+}
+
+// valueRef is a value of a variant written in a spec, along with its location.
+type valueRef struct {
+	variant string
+	value   string
+	node    *node
+	path    string
+}
+
+// compoundDef is a single compound variant of a spec.
+type compoundDef struct {
+	conditions []conditionDef
+	classes    string
+}
+
+// conditionDef constrains a compound variant to a single value of a variant, or to any of a list
+// of values.
+type conditionDef struct {
+	variant string
+	values  []valueRef
+	list    bool
+}
+
+// reporter collects the problems found in a spec.
+type reporter struct {
+	problems []Problem
+}
+
+// problemf records a problem at the given node.
+func (r *reporter) problemf(n *node, path string, format string, args ...any) {
+	r.problems = append(r.problems, Problem{
+		Path:    path,
+		Line:    n.line,
+		Column:  n.column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// expect records a problem if the node is not of the given kind.
+func (r *reporter) expect(n *node, path string, kind nodeKind) bool {
+	if n.kind != kind {
+		r.problemf(n, path, "expected %s, got %s", kind, n.kind)
+		return false
+	}
+	return true
+}
+
+// parseDefinition reads the component definition of a parsed spec, recording the structural
+// problems found. Invalid entries are left out of the definition.
+func parseDefinition(root *node, r *reporter) *definition {
+	d := &definition{}
+	var variants, defaults, compounds *node
+
+	keys, items := r.entries(root, "")
+	for i, k := range keys {
+		key, n := k.value, items[i]
+		switch key {
+		case "name":
+			if r.expect(n, key, scalarNode) {
+				d.name = n.value
+			}
+		case "base":
+			d.base, d.hasBase = r.classes(n, key)
+		case "variants":
+			variants = n
+		case "defaultVariants":
+			defaults = n
+		case "compoundVariants":
+			compounds = n
+		default:
+			r.problemf(k, key, "unknown key")
+		}
+	}
+
+	if variants != nil {
+		d.parseVariants(variants, r)
+	}
+	if defaults != nil {
+		d.parseDefaults(defaults, r)
+	}
+	if compounds != nil {
+		d.parseCompounds(compounds, r)
+	}
+	return d
+}
+
+// variant returns the named variant, or nil if it is not declared.
+func (d *definition) variant(name string) *variantDef {
+	for _, v := range d.variants {
+		if v.name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// parseVariants reads the variants of the spec.
+func (d *definition) parseVariants(n *node, r *reporter) {
+	names, items := r.entries(n, "variants")
+	for i, k := range names {
+		v := &variantDef{name: k.value, key: k, path: childPath("variants", k.value)}
+		keys, values := r.entries(items[i], v.path)
+		for j, key := range keys {
+			path := childPath(v.path, key.value)
+			if classes, ok := r.classes(values[j], path); ok {
+				v.cases = append(v.cases, caseDef{
+					value:   valueRef{variant: v.name, value: key.value, node: key, path: path},
+					classes: classes,
+				})
+			}
+		}
+		d.variants = append(d.variants, v)
+	}
+}
+
+// parseDefaults reads the default values of the spec's variants.
+func (d *definition) parseDefaults(n *node, r *reporter) {
+	names, items := r.entries(n, "defaultVariants")
+	for i, k := range names {
+		path := childPath("defaultVariants", k.value)
+		if d.variant(k.value) == nil {
+			r.problemf(items[i], path, "variant %q is not declared in variants", k.value)
+			continue
+		}
+		if r.expect(items[i], path, scalarNode) {
+			d.defaults = append(d.defaults, valueRef{
+				variant: k.value,
+				value:   items[i].value,
+				node:    items[i],
+				path:    path,
+			})
+		}
+	}
+}
+
+// parseCompounds reads the compound variants of the spec.
+func (d *definition) parseCompounds(n *node, r *reporter) {
+	if !r.expect(n, "compoundVariants", sequenceNode) {
+		return
+	}
+
+	for i, item := range n.items {
+		path := fmt.Sprintf("compoundVariants[%d]", i)
+		keys, items := r.entries(item, path)
+		if keys == nil && item.kind != mappingNode {
+			continue
+		}
+
+		var cmp compoundDef
+		hasClasses, valid := false, true
+		for j, k := range keys {
+			key := k.value
+			keyPath := childPath(path, key)
+			if key == "class" || key == "className" {
+				if hasClasses {
+					r.problemf(k, keyPath, "class and className are mutually exclusive")
+				}
+				var ok bool
+				cmp.classes, ok = r.classes(items[j], keyPath)
+				hasClasses, valid = true, valid && ok
+				continue
+			}
+			if cond, ok := d.parseCondition(key, items[j], keyPath, r); ok {
+				cmp.conditions = append(cmp.conditions, cond)
+			} else {
+				valid = false
+			}
+		}
+		if !hasClasses {
+			r.problemf(item, path, "missing class")
+			continue
+		}
+		if valid {
+			d.compounds = append(d.compounds, cmp)
+		}
+	}
+}
+
+// parseCondition reads a compound variant's condition on the named variant, matching a single
+// value or any of a sequence of values.
+func (d *definition) parseCondition(
+	name string,
+	n *node,
+	path string,
+	r *reporter,
+) (conditionDef, bool) {
+	if d.variant(name) == nil {
+		r.problemf(n, path, "variant %q is not declared in variants", name)
+		return conditionDef{}, false
+	}
+
+	cond := conditionDef{variant: name}
+	switch n.kind {
+	case scalarNode:
+		cond.values = []valueRef{{variant: name, value: n.value, node: n, path: path}}
+		return cond, true
+	case sequenceNode:
+		cond.list = true
+		valid := true
+		for i, item := range n.items {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if r.expect(item, itemPath, scalarNode) {
+				cond.values = append(cond.values, valueRef{
+					variant: name,
+					value:   item.value,
+					node:    item,
+					path:    itemPath,
+				})
+			} else {
+				valid = false
+			}
+		}
+		return cond, valid
+	}
+	r.problemf(n, path, "expected a scalar or a sequence, got %s", n.kind)
+	return conditionDef{}, false
+}
+
+// entries returns the key nodes and values of a mapping node, recording problems for non-scalar
+// and duplicate keys.
+func (r *reporter) entries(n *node, path string) ([]*node, []*node) {
+	if !r.expect(n, path, mappingNode) {
+		return nil, nil
+	}
+	var keys, items []*node
+	seen := make(map[string]bool)
+	for i, k := range n.keys {
+		if k.kind != scalarNode {
+			r.problemf(k, path, "expected a scalar key, got %s", k.kind)
+			continue
+		}
+		if seen[k.value] {
+			r.problemf(k, childPath(path, k.value), "duplicate key")
+			continue
+		}
+		seen[k.value] = true
+		keys = append(keys, k)
+		items = append(items, n.items[i])
+	}
+	return keys, items
+}
+
+// classes returns the class list of a node holding a string or a sequence of strings.
+func (r *reporter) classes(n *node, path string) (string, bool) {
+	switch n.kind {
+	case nullNode:
+		return "", true
+	case scalarNode:
+		return n.value, true
+	case sequenceNode:
+		classes := make([]string, 0, len(n.items))
+		valid := true
+		for i, item := range n.items {
+			if r.expect(item, fmt.Sprintf("%s[%d]", path, i), scalarNode) {
+				classes = append(classes, item.value)
+			} else {
+				valid = false
+			}
+		}
+		return cva.JoinClasses(classes...), valid
+	}
+	r.problemf(n, path, "expected a class list, got %s", n.kind)
+	return "", false
+}
+
+// childPath returns the path of the given key of the mapping at path. Keys that are not plain
+// identifiers are quoted.
+func childPath(path, key string) string {
+	if key == "" || strings.ContainsAny(key, ".[]\" ") {
+		key = fmt.Sprintf("%q", key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
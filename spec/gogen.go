@@ -0,0 +1,342 @@
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GoConfig configures the Go source code generated by GenerateGo.
+type GoConfig struct {
+	// Package is the name of the package of the generated code.
+	Package string
+	// Component is the name of the variable holding the generated component, e.g. "Button". It
+	// defaults to the spec's name, capitalized.
+	Component string
+	// Props is the name of the generated props struct type. It defaults to the component's name
+	// followed by "Props", e.g. "ButtonProps".
+	Props string
+}
+
+// GenerateGo returns Go source code building the component defined by a spec document with
+// cva.New, for porting components defined with the upstream cva library to Go. The spec's format
+// matches the configuration object passed to cva, so that JSON exports of cva definitions can be
+// imported as is.
+//
+// Each variant is read from a field of a generated props struct. Variants whose values are all
+// "true" or "false" are read from bool fields, unless they default to true or, without a default,
+// map false to classes, since a bool field cannot tell false from a missing prop; the others get a
+// string type of their own, with a constant for each of their values. It returns an *Error listing
+// every problem found in the spec.
+func GenerateGo(data []byte, format Format, config GoConfig) ([]byte, error) {
+	d, r, err := read(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Package == "" || !token.IsIdentifier(config.Package) {
+		return nil, fmt.Errorf("spec: Package %q is not a valid package name", config.Package)
+	}
+	if config.Component == "" {
+		config.Component = identifier(d.name)
+	}
+	if !token.IsIdentifier(config.Component) || !token.IsExported(config.Component) {
+		return nil, fmt.Errorf(
+			"spec: Component %q is not an exported identifier, set GoConfig.Component",
+			config.Component,
+		)
+	}
+	if config.Props == "" {
+		config.Props = config.Component + "Props"
+	}
+
+	g := &goGenerator{reporter: r, config: config, def: d}
+	g.collect()
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	return g.code()
+}
+
+// GenerateGoFile is like GenerateGo, but reads the spec from a file, whose format is determined by
+// its extension as with LoadFile.
+func GenerateGoFile(path string, config GoConfig) ([]byte, error) {
+	format, data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	code, err := GenerateGo(data, format, config)
+	return code, withFile(err, path)
+}
+
+// goGenerator generates the Go source code of a spec's component.
+type goGenerator struct {
+	*reporter
+	config   GoConfig
+	def      *definition
+	variants []*goVariant
+}
+
+// goVariant is a variant of the generated component.
+type goVariant struct {
+	def *variantDef
+	// field is the name of the props field holding the variant, and typ its type.
+	field string
+	typ   string
+	// ident is the name of the variable holding the cva.Variant.
+	ident string
+	// values lists the variant's values in the order they first appear in the spec, and consts
+	// maps them to the names of their constants.
+	values []string
+	consts map[string]string
+	isBool bool
+}
+
+// collect names the props fields, types and constants of the spec's variants, recording problems
+// for names that cannot be derived or collide.
+func (g *goGenerator) collect() {
+	refs := make(map[string][]valueRef)
+	for _, v := range g.def.variants {
+		for _, c := range v.cases {
+			refs[v.name] = append(refs[v.name], c.value)
+		}
+	}
+	for _, ref := range g.def.defaults {
+		refs[ref.variant] = append(refs[ref.variant], ref)
+	}
+	for _, cmp := range g.def.compounds {
+		for _, cond := range cmp.conditions {
+			refs[cond.variant] = append(refs[cond.variant], cond.values...)
+		}
+	}
+
+	fields := make(map[string]string)
+	for _, v := range g.def.variants {
+		gv := &goVariant{def: v, field: identifier(v.name), consts: make(map[string]string)}
+		if gv.field == "" {
+			g.problemf(v.key, v.path, "cannot derive a Go field name from variant %q", v.name)
+			continue
+		}
+		if other, ok := fields[gv.field]; ok {
+			g.problemf(v.key, v.path, "variants %q and %q both map to field %s",
+				other, v.name, gv.field)
+			continue
+		}
+		fields[gv.field] = v.name
+		gv.typ = g.config.Component + gv.field
+		component := []rune(g.config.Component)
+		gv.ident = string(unicode.ToLower(component[0])) + string(component[1:]) + gv.field
+
+		// A bool field cannot tell false from a missing prop, so variants defaulting to true get a
+		// type of their own, as do variants without a default mapping false to classes, which cva
+		// only applies when the prop is set.
+		gv.isBool = len(refs[v.name]) > 0
+		for _, ref := range refs[v.name] {
+			gv.isBool = gv.isBool && (ref.value == "true" || ref.value == "false")
+		}
+		hasDefault := false
+		for _, ref := range g.def.defaults {
+			gv.isBool = gv.isBool && (ref.variant != v.name || ref.value != "true")
+			hasDefault = hasDefault || ref.variant == v.name
+		}
+		for _, c := range v.cases {
+			gv.isBool = gv.isBool && (hasDefault || c.value.value != "false" ||
+				strings.TrimSpace(c.classes) == "")
+		}
+
+		names := make(map[string]string)
+		for _, ref := range refs[v.name] {
+			if _, ok := gv.consts[ref.value]; ok {
+				continue
+			}
+			name := gv.typ + words(ref.value)
+			if !gv.isBool && name == gv.typ {
+				g.problemf(ref.node, ref.path, "cannot derive a Go constant name from value %q",
+					ref.value)
+				continue
+			}
+			if other, ok := names[name]; ok {
+				g.problemf(ref.node, ref.path, "values %q and %q both map to constant %s",
+					other, ref.value, name)
+				continue
+			}
+			names[name] = ref.value
+			gv.values = append(gv.values, ref.value)
+			gv.consts[ref.value] = name
+		}
+		g.variants = append(g.variants, gv)
+	}
+
+	for _, ref := range g.def.defaults {
+		if !g.hasCase(ref.variant, ref.value) {
+			g.problemf(ref.node, ref.path, "%q is not a value of variant %q", ref.value, ref.variant)
+		}
+	}
+}
+
+// hasCase reports whether the named variant maps the given value to a class list.
+func (g *goGenerator) hasCase(variant, value string) bool {
+	for _, c := range g.def.variant(variant).cases {
+		if c.value.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// variant returns the generated variant with the given name.
+func (g *goGenerator) variant(name string) *goVariant {
+	for _, v := range g.variants {
+		if v.def.name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// literal returns the Go expression of a value of the variant.
+func (v *goVariant) literal(value string) string {
+	if v.isBool {
+		return value
+	}
+	return v.consts[value]
+}
+
+// code returns the formatted source code of the generated component.
+func (g *goGenerator) code() ([]byte, error) {
+	var b bytes.Buffer
+	c, props := g.config.Component, g.config.Props
+	fmt.Fprintf(&b, "package %s\n\nimport \"github.com/Roundaround/cva-go\"\n\n", g.config.Package)
+
+	for _, v := range g.variants {
+		if v.isBool {
+			continue
+		}
+		fmt.Fprintf(&b, "// %s is a value of the %q variant of %s.\n", v.typ, v.def.name, c)
+		fmt.Fprintf(&b, "type %s string\n\n", v.typ)
+		if len(v.values) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "// Values of the %q variant of %s.\nconst (\n", v.def.name, c)
+		for _, value := range v.values {
+			fmt.Fprintf(&b, "%s %s = %s\n", v.consts[value], v.typ, strconv.Quote(value))
+		}
+		b.WriteString(")\n\n")
+	}
+
+	fmt.Fprintf(&b, "// %s holds the props of %s.\ntype %s struct {\n", props, c, props)
+	for _, v := range g.variants {
+		typ := v.typ
+		if v.isBool {
+			typ = "bool"
+		}
+		fmt.Fprintf(&b, "%s %s `cva:%s`\n", v.field, typ, strconv.Quote(v.def.name))
+	}
+	b.WriteString("}\n\n")
+
+	if len(g.variants) > 0 {
+		b.WriteString("var (\n")
+		for _, v := range g.variants {
+			typ := v.typ
+			if v.isBool {
+				typ = "bool"
+			}
+			fmt.Fprintf(&b, "%s = cva.NewVariant(func(p %s) %s { return p.%s }).\nWithName(%q)",
+				v.ident, props, typ, v.field, v.def.name)
+			for _, ref := range g.def.defaults {
+				if ref.variant == v.def.name {
+					fmt.Fprintf(&b, ".\nWithDefault(%s)", v.literal(ref.value))
+				}
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(")\n\n")
+	}
+
+	if g.def.name != "" {
+		fmt.Fprintf(&b, "// %s was imported from the cva definition of the %q component.\n",
+			c, g.def.name)
+	} else {
+		fmt.Fprintf(&b, "// %s was imported from a cva definition.\n", c)
+	}
+	fmt.Fprintf(&b, "var %s = cva.New(\n", c)
+	if g.def.name != "" {
+		fmt.Fprintf(&b, "cva.Name[%s](%q),\n", props, g.def.name)
+	}
+	if g.def.hasBase {
+		fmt.Fprintf(&b, "cva.Base[%s](%s),\n", props, strconv.Quote(g.def.base))
+	}
+	for _, v := range g.variants {
+		if len(v.def.cases) == 0 {
+			continue
+		}
+		typ := v.typ
+		if v.isBool {
+			typ = "bool"
+		}
+		fmt.Fprintf(&b, "%s.Map(map[%s]string{\n", v.ident, typ)
+		for _, cs := range v.def.cases {
+			fmt.Fprintf(&b, "%s: %s,\n", v.literal(cs.value.value), strconv.Quote(cs.classes))
+		}
+		b.WriteString("}),\n")
+	}
+	for _, cmp := range g.def.compounds {
+		matchers := make([]string, len(cmp.conditions))
+		for i, cond := range cmp.conditions {
+			v := g.variant(cond.variant)
+			vals := make([]string, len(cond.values))
+			for j, ref := range cond.values {
+				vals[j] = v.literal(ref.value)
+			}
+			method := "Is"
+			if cond.list {
+				method = "In"
+			}
+			matchers[i] = fmt.Sprintf("%s.%s(%s)", v.ident, method, strings.Join(vals, ", "))
+		}
+		switch len(matchers) {
+		case 0:
+			fmt.Fprintf(&b, "cva.All[%s]()", props)
+		case 1:
+			b.WriteString(matchers[0])
+		default:
+			fmt.Fprintf(&b, "cva.All(\n%s,\n)", strings.Join(matchers, ",\n"))
+		}
+		fmt.Fprintf(&b, ".Then(%s),\n", strconv.Quote(cmp.classes))
+	}
+	b.WriteString(")\n")
+
+	return format.Source(b.Bytes())
+}
+
+// identifier returns an exported Go identifier derived from s, capitalizing each of its words, or
+// an empty string if s has no letters or digits or starts with a digit.
+func identifier(s string) string {
+	id := words(s)
+	if id == "" || unicode.IsDigit([]rune(id)[0]) {
+		return ""
+	}
+	return id
+}
+
+// words returns the letters and digits of s, capitalizing the first letter of each of its words.
+func words(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package spec
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Roundaround/cva-go/spec/internal/shadcn"
+)
+
+var update = flag.Bool("update", false, "rewrite the generated files of the test package")
+
+func TestGenerateGo(t *testing.T) {
+	path := "internal/shadcn/button.go"
+	config := GoConfig{Package: "shadcn"}
+
+	got, err := GenerateGoFile("testdata/shadcn_button.json", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generated code differs from %s, run go test with -update", path)
+	}
+}
+
+func TestGeneratedComponent(t *testing.T) {
+	loaded, err := LoadFile[map[string]string]("testdata/shadcn_button.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	variants := []shadcn.ButtonVariant{"", shadcn.ButtonVariantDefault,
+		shadcn.ButtonVariantDestructive, shadcn.ButtonVariantOutline, shadcn.ButtonVariantLink}
+	sizes := []shadcn.ButtonSize{"", shadcn.ButtonSizeDefault, shadcn.ButtonSizeSm,
+		shadcn.ButtonSizeLg, shadcn.ButtonSizeIconSm, shadcn.ButtonSize2xl}
+	fullWidths := []shadcn.ButtonFullWidth{"", shadcn.ButtonFullWidthTrue,
+		shadcn.ButtonFullWidthFalse}
+	// Outlined maps false to classes without a default, so that a missing prop applies neither
+	// class list, and cannot be a bool.
+	outlineds := []shadcn.ButtonOutlined{"", shadcn.ButtonOutlinedTrue, shadcn.ButtonOutlinedFalse}
+
+	for _, variant := range variants {
+		for _, size := range sizes {
+			for _, fullWidth := range fullWidths {
+				for _, outlined := range outlineds {
+					for _, loading := range []bool{false, true} {
+						props := shadcn.ButtonProps{
+							Variant:   variant,
+							Size:      size,
+							Loading:   loading,
+							FullWidth: fullWidth,
+							Outlined:  outlined,
+						}
+						dynamic := map[string]string{
+							"variant":   string(variant),
+							"size":      string(size),
+							"fullWidth": string(fullWidth),
+							"outlined":  string(outlined),
+						}
+						if loading {
+							dynamic["loading"] = "true"
+						}
+
+						got := shadcn.Button.Classes(props)
+						want := loaded.Classes(dynamic)
+						if got != want {
+							t.Errorf("%+v: got %s, want %s", props, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateGoErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   string
+		config GoConfig
+		want   []string
+	}{
+		{
+			name: "names",
+			spec: `variants:
+  full-width: {a: x}
+  fullWidth: {a: y}
+  "2x": {a: z}
+  size:
+    icon-sm: a
+    icon_sm: b
+    "!": c
+defaultVariants:
+  size: lg
+`,
+			config: GoConfig{Package: "p", Component: "Button"},
+			want: []string{
+				`3:3: variants.fullWidth: variants "full-width" and "fullWidth" both map to field FullWidth`,
+				`4:3: variants.2x: cannot derive a Go field name from variant "2x"`,
+				`7:5: variants.size.icon_sm: values "icon-sm" and "icon_sm" both map to constant ButtonSizeIconSm`,
+				`8:5: variants.size.!: cannot derive a Go constant name from value "!"`,
+				`10:9: defaultVariants.size: "lg" is not a value of variant "size"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GenerateGo([]byte(test.spec), YAML, test.config)
+			var specErr *Error
+			if !errors.As(err, &specErr) {
+				t.Fatalf("got %v, want *Error", err)
+			}
+			got := make([]string, len(specErr.Problems))
+			for i, p := range specErr.Problems {
+				got[i] = p.String()
+			}
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+			}
+		})
+	}
+
+	t.Run("config", func(t *testing.T) {
+		_, err := GenerateGo([]byte("base: a\n"), YAML, GoConfig{Package: "p"})
+		if err == nil || !strings.Contains(err.Error(), "set GoConfig.Component") {
+			t.Errorf("got %v, want missing component name", err)
+		}
+		_, err = GenerateGo([]byte("name: button\n"), YAML, GoConfig{})
+		if err == nil || !strings.Contains(err.Error(), "not a valid package name") {
+			t.Errorf("got %v, want missing package name", err)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package shadcn
+
+import "github.com/Roundaround/cva-go"
+
+// ButtonVariant is a value of the "variant" variant of Button.
+type ButtonVariant string
+
+// Values of the "variant" variant of Button.
+const (
+	ButtonVariantDefault     ButtonVariant = "default"
+	ButtonVariantDestructive ButtonVariant = "destructive"
+	ButtonVariantOutline     ButtonVariant = "outline"
+	ButtonVariantLink        ButtonVariant = "link"
+)
+
+// ButtonSize is a value of the "size" variant of Button.
+type ButtonSize string
+
+// Values of the "size" variant of Button.
+const (
+	ButtonSizeDefault ButtonSize = "default"
+	ButtonSizeSm      ButtonSize = "sm"
+	ButtonSizeLg      ButtonSize = "lg"
+	ButtonSizeIconSm  ButtonSize = "icon-sm"
+	ButtonSize2xl     ButtonSize = "2xl"
+)
+
+// ButtonFullWidth is a value of the "fullWidth" variant of Button.
+type ButtonFullWidth string
+
+// Values of the "fullWidth" variant of Button.
+const (
+	ButtonFullWidthTrue  ButtonFullWidth = "true"
+	ButtonFullWidthFalse ButtonFullWidth = "false"
+)
+
+// ButtonOutlined is a value of the "outlined" variant of Button.
+type ButtonOutlined string
+
+// Values of the "outlined" variant of Button.
+const (
+	ButtonOutlinedTrue  ButtonOutlined = "true"
+	ButtonOutlinedFalse ButtonOutlined = "false"
+)
+
+// ButtonProps holds the props of Button.
+type ButtonProps struct {
+	Variant   ButtonVariant   `cva:"variant"`
+	Size      ButtonSize      `cva:"size"`
+	Loading   bool            `cva:"loading"`
+	FullWidth ButtonFullWidth `cva:"fullWidth"`
+	Outlined  ButtonOutlined  `cva:"outlined"`
+}
+
+var (
+	buttonVariant = cva.NewVariant(func(p ButtonProps) ButtonVariant { return p.Variant }).
+			WithName("variant").
+			WithDefault(ButtonVariantDefault)
+	buttonSize = cva.NewVariant(func(p ButtonProps) ButtonSize { return p.Size }).
+			WithName("size").
+			WithDefault(ButtonSizeDefault)
+	buttonLoading = cva.NewVariant(func(p ButtonProps) bool { return p.Loading }).
+			WithName("loading")
+	buttonFullWidth = cva.NewVariant(func(p ButtonProps) ButtonFullWidth { return p.FullWidth }).
+			WithName("fullWidth").
+			WithDefault(ButtonFullWidthTrue)
+	buttonOutlined = cva.NewVariant(func(p ButtonProps) ButtonOutlined { return p.Outlined }).
+			WithName("outlined")
+)
+
+// Button was imported from the cva definition of the "button" component.
+var Button = cva.New(
+	cva.Name[ButtonProps]("button"),
+	cva.Base[ButtonProps]("inline-flex items-center justify-center gap-2 whitespace-nowrap rounded-md text-sm font-medium transition-colors"),
+	buttonVariant.Map(map[ButtonVariant]string{
+		ButtonVariantDefault:     "bg-primary text-primary-foreground hover:bg-primary/90",
+		ButtonVariantDestructive: "bg-destructive text-white hover:bg-destructive/90",
+		ButtonVariantOutline:     "border bg-background hover:bg-accent",
+		ButtonVariantLink:        "text-primary underline-offset-4 hover:underline",
+	}),
+	buttonSize.Map(map[ButtonSize]string{
+		ButtonSizeDefault: "h-9 px-4 py-2",
+		ButtonSizeSm:      "h-8 rounded-md px-3",
+		ButtonSizeLg:      "h-10 rounded-md px-6",
+		ButtonSizeIconSm:  "size-8",
+		ButtonSize2xl:     "h-12 px-8",
+	}),
+	buttonLoading.Map(map[bool]string{
+		true: "cursor-wait opacity-70",
+	}),
+	buttonFullWidth.Map(map[ButtonFullWidth]string{
+		ButtonFullWidthTrue:  "w-full",
+		ButtonFullWidthFalse: "w-auto",
+	}),
+	buttonOutlined.Map(map[ButtonOutlined]string{
+		ButtonOutlinedTrue:  "border",
+		ButtonOutlinedFalse: "border-0",
+	}),
+	cva.All(
+		buttonVariant.In(ButtonVariantDefault, ButtonVariantDestructive),
+		buttonLoading.Is(true),
+	).Then("animate-pulse"),
+	cva.All(
+		buttonSize.Is(ButtonSizeIconSm),
+		buttonFullWidth.Is(ButtonFullWidthTrue),
+	).Then("aspect-square w-8"),
+)
@@ -0,0 +1,215 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// nodeKind is the kind of a node of a parsed spec document.
+type nodeKind int
+
+const (
+	nullNode nodeKind = iota
+	scalarNode
+	mappingNode
+	sequenceNode
+)
+
+// String returns a description of the node kind for use in problem messages.
+func (k nodeKind) String() string {
+	switch k {
+	case scalarNode:
+		return "a scalar"
+	case mappingNode:
+		return "a mapping"
+	case sequenceNode:
+		return "a sequence"
+	}
+	return "null"
+}
+
+// node is a value of a parsed spec document, independent of its format. Mappings keep the order
+// of their keys, and every node records its position in the document.
+type node struct {
+	kind   nodeKind
+	value  string
+	keys   []*node
+	items  []*node
+	line   int
+	column int
+}
+
+// syntaxError is returned when a spec document cannot be parsed.
+type syntaxError struct {
+	line, column int
+	msg          string
+}
+
+func (e *syntaxError) Error() string {
+	return e.msg
+}
+
+// parseYAML parses a YAML document.
+func parseYAML(data []byte) (*node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, &syntaxError{msg: err.Error()}
+	}
+	if doc.Kind == 0 {
+		return &node{kind: nullNode, line: 1, column: 1}, nil
+	}
+	return fromYAML(&doc), nil
+}
+
+// fromYAML converts a YAML node, resolving documents and aliases.
+func fromYAML(y *yaml.Node) *node {
+	switch y.Kind {
+	case yaml.DocumentNode:
+		return fromYAML(y.Content[0])
+	case yaml.AliasNode:
+		return fromYAML(y.Alias)
+	}
+
+	n := &node{line: y.Line, column: y.Column}
+	switch y.Kind {
+	case yaml.ScalarNode:
+		if y.Tag == "!!null" {
+			n.kind = nullNode
+			break
+		}
+		n.kind = scalarNode
+		n.value = y.Value
+	case yaml.MappingNode:
+		n.kind = mappingNode
+		for i := 0; i+1 < len(y.Content); i += 2 {
+			n.keys = append(n.keys, fromYAML(y.Content[i]))
+			n.items = append(n.items, fromYAML(y.Content[i+1]))
+		}
+	case yaml.SequenceNode:
+		n.kind = sequenceNode
+		for _, item := range y.Content {
+			n.items = append(n.items, fromYAML(item))
+		}
+	}
+	return n
+}
+
+// jsonParser parses a JSON document into nodes, tracking their positions.
+type jsonParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// parseJSON parses a JSON document.
+func parseJSON(data []byte) (*node, error) {
+	p := &jsonParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+	n, err := p.value()
+	if err != nil {
+		return nil, p.wrap(err)
+	}
+	if _, err := p.dec.Token(); err != io.EOF {
+		line, column := p.position(p.dec.InputOffset())
+		return nil, &syntaxError{line: line, column: column, msg: "unexpected data after document"}
+	}
+	return n, nil
+}
+
+// value parses the next JSON value.
+func (p *jsonParser) value() (*node, error) {
+	line, column := p.position(p.dec.InputOffset())
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &node{line: line, column: column}
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '{' {
+			n.kind = mappingNode
+			for p.dec.More() {
+				key, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				val, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key)
+				n.items = append(n.items, val)
+			}
+		} else {
+			n.kind = sequenceNode
+			for p.dec.More() {
+				item, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, item)
+			}
+		}
+		if _, err := p.dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.kind, n.value = scalarNode, tok
+	case json.Number:
+		n.kind, n.value = scalarNode, tok.String()
+	case bool:
+		n.kind, n.value = scalarNode, strconv.FormatBool(tok)
+	case nil:
+		n.kind = nullNode
+	}
+	return n, nil
+}
+
+// position returns the line and column of the first token at or after the given offset, skipping
+// whitespace and separators.
+func (p *jsonParser) position(offset int64) (int, int) {
+	for offset < int64(len(p.data)) {
+		switch p.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+			continue
+		}
+		break
+	}
+	return p.lineColumn(offset)
+}
+
+// lineColumn returns the line and column of the byte at the given offset.
+func (p *jsonParser) lineColumn(offset int64) (int, int) {
+	line, column := 1, 1
+	for _, b := range p.data[:offset] {
+		if b == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// wrap converts a JSON decoding error into a syntaxError.
+func (p *jsonParser) wrap(err error) error {
+	// Syntax errors occur after reading the offending byte.
+	var syntaxErr *json.SyntaxError
+	offset := p.dec.InputOffset()
+	if errors.As(err, &syntaxErr) {
+		offset = max(syntaxErr.Offset-1, 0)
+	}
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	line, column := p.lineColumn(min(offset, int64(len(p.data))))
+	return &syntaxError{line: line, column: column, msg: fmt.Sprintf("invalid JSON: %s", err)}
+}
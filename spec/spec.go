@@ -0,0 +1,315 @@
+// Package spec builds cva components from declarative JSON or YAML specs, so that variant tables
+// can be maintained outside of Go code.
+//
+// A spec follows the shape of the original cva configuration object:
+//
+//	name: button
+//	base: inline-flex items-center justify-center
+//	variants:
+//	  size:
+//	    small: h-9 px-3
+//	    medium: h-10 px-4 py-2
+//	  disabled:
+//	    "true": opacity-50
+//	defaultVariants:
+//	  size: medium
+//	compoundVariants:
+//	  - size: [small, medium]
+//	    disabled: "true"
+//	    class: cursor-not-allowed
+//
+// Class lists may be written as strings or sequences of strings. Variants and compound variants
+// are applied in the order they appear in the spec, after the base classes. Compound variants
+// match a single value or, when given a sequence, any of a set of values of each listed variant.
+//
+// The props type of the built component is either map[string]string, in which case variants read
+// the prop of the same name, or a struct type, in which case each variant reads the exported field
+// whose cva or json struct tag name, or whose name compared case-insensitively, matches the
+// variant's name. Struct fields must be of a bool, string, integer or floating-point kind, and
+// the spec's values are parsed as values of the field's type. Fields promoted from embedded
+// structs are read too, as missing values when promoted through a nil pointer.
+//
+// GenerateGo goes the other way round, generating Go source code for the component along with its
+// props struct, for porting components defined with the upstream cva library to Go.
+package spec
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Roundaround/cva-go"
+)
+
+// Format is the format of a spec document.
+type Format int
+
+const (
+	// JSON is the JSON format.
+	JSON Format = iota
+	// YAML is the YAML format.
+	YAML
+)
+
+// Problem describes a single problem found in a spec.
+type Problem struct {
+	// Path locates the offending value in the spec, e.g. "variants.size.small" or
+	// "compoundVariants[1].class". It is empty for syntax errors.
+	Path string
+	// Line and Column locate the offending value in the spec document, if known.
+	Line   int
+	Column int
+	// Message describes the problem.
+	Message string
+}
+
+// String returns a human-readable description of the problem.
+func (p Problem) String() string {
+	var b strings.Builder
+	if p.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", p.Line, p.Column)
+	}
+	if p.Path != "" {
+		b.WriteString(p.Path)
+		b.WriteString(": ")
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// Error is returned when a spec cannot be parsed or built.
+type Error struct {
+	// File is the path of the spec file, if loaded with LoadFile.
+	File string
+	// Problems lists every problem found, in the order they appear in the spec.
+	Problems []Problem
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("spec: invalid component spec")
+	if e.File != "" {
+		fmt.Fprintf(&b, " %s", e.File)
+	}
+	b.WriteString(":")
+	for _, p := range e.Problems {
+		b.WriteString("\n\t")
+		b.WriteString(p.String())
+	}
+	return b.String()
+}
+
+// Load builds a component from a spec document in the given format. It returns an *Error listing
+// every problem found in the spec.
+func Load[P any](data []byte, format Format) (*cva.Cva[P], error) {
+	b, err := newBinder[P]()
+	if err != nil {
+		return nil, err
+	}
+	d, r, err := read(data, format)
+	if err != nil {
+		return nil, err
+	}
+	s := &builder[P]{
+		reporter: r,
+		binder:   b,
+		variants: make(map[string]*cva.Variant[P, any]),
+		values:   make(map[string]map[any]string),
+	}
+	c := s.build(d)
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadFile builds a component from a spec file, whose format is determined by its extension:
+// .json for JSON, and .yaml or .yml for YAML. Errors found in the spec are reported as an *Error
+// including the file's path.
+func LoadFile[P any](path string) (*cva.Cva[P], error) {
+	format, data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c, err := Load[P](data, format)
+	return c, withFile(err, path)
+}
+
+// read parses a spec document and reads its component definition, returning the reporter holding
+// the structural problems found.
+func read(data []byte, format Format) (*definition, *reporter, error) {
+	var root *node
+	var err error
+	switch format {
+	case JSON:
+		root, err = parseJSON(data)
+	case YAML:
+		root, err = parseYAML(data)
+	default:
+		return nil, nil, fmt.Errorf("spec: unknown format %d", format)
+	}
+	if err != nil {
+		se := err.(*syntaxError)
+		return nil, nil, &Error{
+			Problems: []Problem{{Line: se.line, Column: se.column, Message: se.msg}},
+		}
+	}
+	r := &reporter{}
+	return parseDefinition(root, r), r, nil
+}
+
+// readFile reads a spec file, determining its format from its extension.
+func readFile(path string) (Format, []byte, error) {
+	var format Format
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = JSON
+	case ".yaml", ".yml":
+		format = YAML
+	default:
+		return 0, nil, fmt.Errorf("spec: unknown format of spec file %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("spec: %w", err)
+	}
+	return format, data, nil
+}
+
+// withFile sets the file of the spec an *Error was found in.
+func withFile(err error, path string) error {
+	if e, ok := err.(*Error); ok {
+		e.File = path
+	}
+	return err
+}
+
+// err returns an *Error listing the problems found, in the order they appear in the spec, or nil.
+func (r *reporter) err() error {
+	if len(r.problems) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(r.problems, func(a, b Problem) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	return &Error{Problems: r.problems}
+}
+
+// builder builds a component from a spec's definition, recording the problems found when binding
+// its variants to the props.
+type builder[P any] struct {
+	*reporter
+	binder   *binder[P]
+	variants map[string]*cva.Variant[P, any]
+	bindings map[string]binding[P]
+	values   map[string]map[any]string
+}
+
+// build builds the component of the definition.
+func (s *builder[P]) build(d *definition) *cva.Cva[P] {
+	var opts []cva.Option[P]
+	if d.name != "" {
+		opts = append(opts, cva.Name[P](d.name))
+	}
+	if d.hasBase {
+		opts = append(opts, cva.Base[P](d.base))
+	}
+
+	s.bindings = make(map[string]binding[P])
+	for _, v := range d.variants {
+		if opt, ok := s.buildVariant(v); ok {
+			opts = append(opts, opt)
+		}
+	}
+
+	for _, ref := range d.defaults {
+		val, ok := s.value(ref)
+		if !ok {
+			continue
+		}
+		if _, ok := s.values[ref.variant][val]; !ok {
+			s.problemf(ref.node, ref.path, "%#v is not a value of variant %q", val, ref.variant)
+			continue
+		}
+		s.variants[ref.variant].WithDefault(val)
+	}
+
+	for _, cmp := range d.compounds {
+		matchers := make([]cva.Matcher[P], 0, len(cmp.conditions))
+		valid := true
+		for _, cond := range cmp.conditions {
+			m, ok := s.condition(cond)
+			matchers = append(matchers, m)
+			valid = valid && ok
+		}
+		if valid {
+			opts = append(opts, cva.All(matchers...).Then(cmp.classes))
+		}
+	}
+	return cva.New(opts...)
+}
+
+// buildVariant creates a variant of the spec, returning its map option.
+func (s *builder[P]) buildVariant(v *variantDef) (cva.Option[P], bool) {
+	b, err := s.binder.bind(v.name)
+	if err != nil {
+		s.problemf(v.key, v.path, "%s", err)
+		return nil, false
+	}
+	s.bindings[v.name] = b
+	variant := cva.NewVariant(b.get).WithName(v.name)
+	s.variants[v.name] = variant
+
+	classesMap := make(map[any]string, len(v.cases))
+	for _, c := range v.cases {
+		val, ok := s.value(c.value)
+		if !ok {
+			continue
+		}
+		if _, ok := classesMap[val]; ok {
+			s.problemf(c.value.node, c.value.path, "duplicate value %#v", val)
+			continue
+		}
+		classesMap[val] = c.classes
+	}
+	s.values[v.name] = classesMap
+	return variant.Map(classesMap), true
+}
+
+// condition creates the matcher of a compound variant's condition.
+func (s *builder[P]) condition(cond conditionDef) (cva.Matcher[P], bool) {
+	vals := make([]any, 0, len(cond.values))
+	valid := true
+	for _, ref := range cond.values {
+		val, ok := s.value(ref)
+		vals = append(vals, val)
+		valid = valid && ok
+	}
+	if !valid {
+		return cva.Matcher[P]{}, false
+	}
+	if cond.list {
+		return s.variants[cond.variant].In(vals...), true
+	}
+	return s.variants[cond.variant].Is(vals[0]), true
+}
+
+// value parses a value of a variant. Values of variants that could not be bound to the props are
+// reported as invalid without recording further problems.
+func (s *builder[P]) value(ref valueRef) (any, bool) {
+	b, ok := s.bindings[ref.variant]
+	if !ok {
+		return nil, false
+	}
+	val, err := b.parse(ref.value)
+	if err != nil {
+		s.problemf(ref.node, ref.path, "%s", err)
+		return nil, false
+	}
+	return val, true
+}
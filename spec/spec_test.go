@@ -0,0 +1,263 @@
+package spec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type Size string
+
+type buttonProps struct {
+	Size     Size `json:"size"`
+	Disabled bool
+}
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		props buttonProps
+		want  string
+	}{
+		{
+			name:  "defaults",
+			props: buttonProps{},
+			want:  "inline-flex items-center justify-center h-10 px-4 py-2",
+		},
+		{
+			name:  "compound",
+			props: buttonProps{Size: "small", Disabled: true},
+			want:  "inline-flex items-center justify-center h-9 px-3 opacity-50 cursor-not-allowed",
+		},
+		{
+			name:  "unknown-value",
+			props: buttonProps{Size: "huge"},
+			want:  "inline-flex items-center justify-center",
+		},
+	}
+
+	for _, path := range []string{"testdata/button.yaml", "testdata/button.json"} {
+		t.Run(path, func(t *testing.T) {
+			button, err := LoadFile[buttonProps](path)
+			if err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got := button.Schema().Name; got != "button" {
+				t.Errorf("got %s, want %s", got, "button")
+			}
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					got := button.Classes(test.props)
+					if got != test.want {
+						t.Errorf("got %s, want %s", got, test.want)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestLoadDynamic(t *testing.T) {
+	button, err := LoadFile[map[string]string]("testdata/button.yaml")
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	tests := []struct {
+		name  string
+		props map[string]string
+		want  string
+	}{
+		{
+			name:  "nil",
+			props: nil,
+			want:  "inline-flex items-center justify-center h-10 px-4 py-2",
+		},
+		{
+			name:  "compound",
+			props: map[string]string{"size": "medium", "disabled": "true"},
+			want:  "inline-flex items-center justify-center h-10 px-4 py-2 opacity-50 cursor-not-allowed",
+		},
+		{
+			name:  "large",
+			props: map[string]string{"size": "large", "disabled": "false"},
+			want:  "inline-flex items-center justify-center h-11 px-8",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := button.Classes(test.props)
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadEmbedded(t *testing.T) {
+	type Base struct {
+		Size string
+	}
+	type Props struct {
+		*Base
+		Disabled bool
+	}
+
+	button, err := Load[Props]([]byte(`
+variants:
+  size: {small: h-9, medium: h-10}
+  disabled: {"true": opacity-50}
+defaultVariants:
+  size: medium
+`), YAML)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	tests := []struct {
+		name  string
+		props Props
+		want  string
+	}{
+		{
+			name:  "nil-base",
+			props: Props{Disabled: true},
+			want:  "h-10 opacity-50",
+		},
+		{
+			name:  "base",
+			props: Props{Base: &Base{Size: "small"}},
+			want:  "h-9",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := button.Classes(test.props)
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadOrder(t *testing.T) {
+	spec := `
+variants:
+  tone: {muted: text-gray-500}
+  size: {small: text-sm}
+  align: {left: text-left}
+`
+	text, err := Load[map[string]string]([]byte(spec), YAML)
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+	got := text.Classes(map[string]string{"size": "small", "align": "left", "tone": "muted"})
+	want := "text-gray-500 text-sm text-left"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   string
+		format Format
+		want   []string
+	}{
+		{
+			name: "yaml",
+			spec: `variants:
+  size:
+    small: h-9
+    tiny: [h-7, {}]
+  color:
+    red: text-red-500
+  disabled:
+    yes: opacity-50
+defaultVariants:
+  size: huge
+compoundVariants:
+  - size: small
+    tone: muted
+    class: a
+  - size: [small, medium]
+slots: {}
+`,
+			format: YAML,
+			want: []string{
+				"4:17: variants.size.tiny[1]: expected a scalar, got a mapping",
+				`5:3: variants.color: no field of spec.buttonProps for variant "color"`,
+				`8:5: variants.disabled.yes: invalid bool value "yes"`,
+				`10:9: defaultVariants.size: "huge" is not a value of variant "size"`,
+				`13:11: compoundVariants[0].tone: variant "tone" is not declared in variants`,
+				"15:5: compoundVariants[1]: missing class",
+				"16:1: slots: unknown key",
+			},
+		},
+		{
+			name: "json",
+			spec: `{
+  "variants": {
+    "size": {"small": 1, "small": "h-9"},
+    "disabled": "true"
+  },
+  "compoundVariants": {}
+}`,
+			format: JSON,
+			want: []string{
+				`3:26: variants.size.small: duplicate key`,
+				"4:17: variants.disabled: expected a mapping, got a scalar",
+				"6:23: compoundVariants: expected a sequence, got a mapping",
+			},
+		},
+		{
+			name:   "json-syntax",
+			spec:   "{\n  \"base\": \"a\",\n  \"variants\": ]\n}",
+			format: JSON,
+			want:   []string{"3:15: invalid JSON: invalid character ']' after object key:value pair"},
+		},
+		{
+			name:   "yaml-syntax",
+			spec:   "base: [a\n",
+			format: YAML,
+			want:   []string{"yaml: line 1: did not find expected ',' or ']'"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Load[buttonProps]([]byte(test.spec), test.format)
+			var specErr *Error
+			if !errors.As(err, &specErr) {
+				t.Fatalf("got %v, want *Error", err)
+			}
+			got := make([]string, len(specErr.Problems))
+			for i, p := range specErr.Problems {
+				got[i] = p.String()
+			}
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	_, err := LoadFile[buttonProps]("testdata/missing.yaml")
+	if err == nil {
+		t.Errorf("got nil error, want missing file")
+	}
+
+	_, err = LoadFile[buttonProps]("testdata/button.toml")
+	if err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("got %v, want unknown format", err)
+	}
+
+	_, err = LoadFile[[]string]("testdata/button.yaml")
+	if err == nil || !strings.Contains(err.Error(), "neither map[string]string nor a struct") {
+		t.Errorf("got %v, want unsupported props type", err)
+	}
+}
@@ -0,0 +1,48 @@
+package cva
+
+import (
+	"io"
+	"iter"
+)
+
+// Tokens returns an iterator over the individual classes of the class list generated for the
+// props, as returned by Classes.
+func (c *Cva[P]) Tokens(props P) iter.Seq[string] {
+	return c.SlotTokens(props, "")
+}
+
+// SlotTokens is like Tokens, but iterates over the classes of the given slot, as returned by Slots,
+// instead of the root element's.
+//
+// The class list is generated when iteration starts, every time the iterator is used.
+func (c *Cva[P]) SlotTokens(props P, slot string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		classes := c.cachedSlotClasses(props, slot)
+		for start := 0; start < len(classes); {
+			end := start
+			for end < len(classes) && classes[end] != ' ' {
+				end++
+			}
+			if !yield(classes[start:end]) {
+				return
+			}
+			start = end + 1
+		}
+	}
+}
+
+// WriteClasses writes the class list generated for the props, as returned by Classes, to w. Like
+// AppendClasses, it builds the class list in a reused buffer rather than an intermediate string.
+func (c *Cva[P]) WriteClasses(w io.Writer, props P) error {
+	return c.WriteSlotClasses(w, props, "")
+}
+
+// WriteSlotClasses is like WriteClasses, but writes the class list of the given slot, as returned
+// by Slots, instead of the root element's.
+func (c *Cva[P]) WriteSlotClasses(w io.Writer, props P, slot string) error {
+	buf := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(buf)
+	*buf = c.AppendSlotClasses((*buf)[:0], props, slot)
+	_, err := w.Write(*buf)
+	return err
+}
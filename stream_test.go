@@ -0,0 +1,120 @@
+package cva
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestStream(t *testing.T) {
+	type Props struct {
+		Size   string
+		Extras []string
+	}
+
+	options := func() []Option[Props] {
+		return []Option[Props]{
+			Base[Props](" button\tpx-4 "),
+			MapVariant(func(p Props) string { return p.Size }, map[string]string{"small": "h-8 px-2"}),
+			Classes(func(p Props) iter.Seq[string] { return slices.Values(p.Extras) }),
+			InSlot("icon", Base[Props]("size-4"), Classes(func(p Props) string { return p.Size })),
+		}
+	}
+
+	props := Props{Size: "small", Extras: []string{" extra-1  extra-2", "", "px-4"}}
+
+	tests := []struct {
+		name string
+		c    *Cva[Props]
+		want string
+	}{
+		{name: "plain", c: New(options()...), want: "button px-4 h-8 px-2 extra-1 extra-2 px-4"},
+		{
+			name: "merged",
+			c:    New(append(options(), WithMerger[Props](Dedupe))...),
+			want: "button px-4 h-8 px-2 extra-1 extra-2",
+		},
+		{
+			name: "cached",
+			c:    New(append(options(), CacheFunc(0, func(p Props) string { return p.Size }))...),
+			want: "button px-4 h-8 px-2 extra-1 extra-2 px-4",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.c.Classes(props); got != test.want {
+				t.Errorf("Classes: got %s, want %s", got, test.want)
+			}
+
+			tokens := slices.Collect(test.c.Tokens(props))
+			if got := strings.Join(tokens, " "); got != test.want {
+				t.Errorf("Tokens: got %s, want %s", got, test.want)
+			}
+			if got, want := slices.Collect(test.c.SlotTokens(props, "icon")), []string{
+				"size-4", "small",
+			}; !slices.Equal(got, want) {
+				t.Errorf("SlotTokens: got %v, want %v", got, want)
+			}
+
+			var b strings.Builder
+			if err := test.c.WriteClasses(&b, props); err != nil {
+				t.Fatalf("got error %v, want nil", err)
+			}
+			if got := b.String(); got != test.want {
+				t.Errorf("WriteClasses: got %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	t.Run("stop_early", func(t *testing.T) {
+		c := New(options()...)
+		var got []string
+		for token := range c.Tokens(props) {
+			if token == "h-8" {
+				break
+			}
+			got = append(got, token)
+		}
+		if want := []string{"button", "px-4"}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		c := New[Props]()
+		for token := range c.Tokens(Props{}) {
+			t.Errorf("got token %q, want none", token)
+		}
+		var b strings.Builder
+		if err := c.WriteSlotClasses(&b, Props{}, "missing"); err != nil || b.Len() != 0 {
+			t.Errorf("got %q, %v, want empty output", b.String(), err)
+		}
+	})
+
+	t.Run("write_error", func(t *testing.T) {
+		if err := New(options()...).WriteClasses(failingWriter{}, props); err == nil {
+			t.Errorf("got nil error, want an error")
+		}
+	})
+
+	t.Run("write_allocations", func(t *testing.T) {
+		c := New(options()[:2]...)
+		var b strings.Builder
+		b.Grow(1024)
+		allocs := testing.AllocsPerRun(10, func() {
+			_ = c.WriteClasses(&b, props)
+		})
+		if allocs != 0 {
+			t.Errorf("got %v allocations, want 0", allocs)
+		}
+	})
+}
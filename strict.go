@@ -0,0 +1,165 @@
+package cva
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// UnknownValue describes a variant value outside of the variant's known domain, as reported by
+// ClassesE and SlotsE.
+type UnknownValue struct {
+	// Variant is the name of the variant, as set with Variant.WithName or Label. It is empty for
+	// unnamed variants.
+	Variant string
+	// Value is the variant's value, as returned by its getter.
+	Value any
+	// Known lists the variant's known values.
+	Known []any
+	// File and Line locate the code that created the option evaluating the variant, if known.
+	File string
+	Line int
+
+	// id identifies the Variant the value was read from, or zero for inline map variants.
+	id uint64
+}
+
+// String returns a human-readable description of the unknown value.
+func (u UnknownValue) String() string {
+	name := u.Variant
+	if name == "" {
+		name = "variant"
+	}
+	s := fmt.Sprintf("%s=%#v not in %v", name, u.Value, u.Known)
+	if u.File != "" {
+		s += fmt.Sprintf(" (%s:%d)", u.File, u.Line)
+	}
+	return s
+}
+
+// UnknownValuesError is returned by ClassesE and SlotsE when the props hold variant values outside
+// of the variants' known domains.
+type UnknownValuesError struct {
+	// Component is the component's name, as set with the Name option.
+	Component string
+	// Values lists every unknown variant value, in the order of the options reading them. A
+	// variant read by several options is only listed once.
+	Values []UnknownValue
+}
+
+// Error implements the error interface.
+func (e *UnknownValuesError) Error() string {
+	values := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		values[i] = v.String()
+	}
+	component := ""
+	if e.Component != "" {
+		component = fmt.Sprintf(" of component %q", e.Component)
+	}
+	return fmt.Sprintf("cva: unknown variant values%s: %s", component, strings.Join(values, ", "))
+}
+
+// ClassesE is a strict version of Classes, which also returns an *UnknownValuesError listing
+// every variant whose value is not in its known domain.
+//
+// The known domain of a variant created with NewVariant is the list of values given to
+// Variant.WithValues, if any, or otherwise the keys of the map given to Variant.Map. The known
+// domain of an inline MapVariant is the keys of its map. Variants without a known domain, such as
+// those only used through matchers without WithValues, are never reported. The zero value of a
+// variant is always accepted, as it stands for an unset prop.
+//
+// The class list is returned even when there is an error, and is identical to the output of
+// Classes.
+func (c *Cva[P]) ClassesE(props P) (string, error) {
+	return c.Classes(props), c.checkValues(props, func(slot string) bool { return slot == "" })
+}
+
+// SlotsE is a strict version of Slots, which also returns an *UnknownValuesError listing every
+// variant of any slot whose value is not in its known domain. See ClassesE for details.
+func (c *Cva[P]) SlotsE(props P) (SlotClasses, error) {
+	return c.Slots(props), c.checkValues(props, func(string) bool { return true })
+}
+
+// checkValues returns an *UnknownValuesError listing the unknown variant values read by the
+// producers of the slots matching the filter, or nil if there are none.
+func (c *Cva[P]) checkValues(props P, inSlot func(string) bool) error {
+	props = c.normalize(props)
+
+	var unknown []UnknownValue
+	for _, p := range c.producers {
+		if !inSlot(p.slot) {
+			continue
+		}
+		for _, u := range p.unknownValues(props) {
+			if u.id != 0 && slices.ContainsFunc(unknown, func(prev UnknownValue) bool {
+				return prev.id == u.id
+			}) {
+				continue
+			}
+			unknown = append(unknown, u)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	return &UnknownValuesError{Component: c.name, Values: unknown}
+}
+
+// unknownValues returns the unknown variant values read by the producer, completed with the
+// producer's variant name and location.
+func (p producer[P]) unknownValues(props P) []UnknownValue {
+	if p.validate == nil {
+		return nil
+	}
+	unknown := p.validate(props)
+	for i := range unknown {
+		u := &unknown[i]
+		if u.Variant == "" && p.info.Kind == KindMap {
+			u.Variant = p.info.Variants[0].Name
+		}
+		if u.File == "" {
+			u.File, u.Line = p.info.File, p.info.Line
+		}
+	}
+	return unknown
+}
+
+// checkKnown returns a validation function reporting the getter's value when it is neither the
+// zero value nor one of the known values. It returns nil if there are no known values.
+func checkKnown[P any, V comparable](
+	getter func(P) V,
+	known []V,
+	name string,
+	id uint64,
+) func(P) []UnknownValue {
+	if known == nil {
+		return nil
+	}
+	return func(p P) []UnknownValue {
+		var zero V
+		val := getter(p)
+		if val == zero || slices.Contains(known, val) {
+			return nil
+		}
+		return []UnknownValue{{Variant: name, Value: val, Known: toAny(known), id: id}}
+	}
+}
+
+// combineValidators returns a validation function reporting the unknown values of all the given
+// validation functions, or nil if there are none.
+func combineValidators[P any](validators ...func(P) []UnknownValue) func(P) []UnknownValue {
+	validators = slices.DeleteFunc(validators, func(fn func(P) []UnknownValue) bool {
+		return fn == nil
+	})
+	if len(validators) == 0 {
+		return nil
+	}
+	return func(p P) []UnknownValue {
+		var unknown []UnknownValue
+		for _, fn := range validators {
+			unknown = append(unknown, fn(p)...)
+		}
+		return unknown
+	}
+}
@@ -0,0 +1,130 @@
+package cva
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	type Props struct {
+		Size     string
+		Style    string
+		Intent   string
+		Count    int
+		Disabled bool
+	}
+
+	size := NewVariant(func(p Props) string { return p.Size }).
+		WithName("size").
+		WithValues("small", "medium", "large").
+		WithDefault("medium")
+	style := NewVariant(func(p Props) string { return p.Style }).WithName("style")
+	intent := NewVariant(func(p Props) string { return p.Intent })
+
+	base := New(
+		Name[Props]("base"),
+		Label("count", MapVariant(func(p Props) int { return p.Count }, map[int]string{1: "one"})),
+	)
+	button := New(
+		Name[Props]("button"),
+		Inherit(base, func(p Props) Props { return p }),
+		size.Map(map[string]string{"small": "h-8", "large": "h-12"}),
+		style.Map(map[string]string{"primary": "bg-blue-500", "link": "underline"}),
+		size.Is("large").And(style.Is("primary")).Then("shadow"),
+		intent.Is("danger").Then("text-red-500"),
+		PredicateVariant(func(p Props) bool { return p.Disabled }, "opacity-50"),
+		InSlot("icon", size.Is("small").Then("size-3"), MapVariant(
+			func(p Props) string { return p.Intent },
+			map[string]string{"danger": "text-red-500"},
+		)),
+	)
+
+	tests := []struct {
+		name      string
+		props     Props
+		want      []string
+		wantSlots []string
+	}{
+		{name: "valid", props: Props{Size: "large", Style: "primary", Count: 1, Disabled: true}},
+		{name: "unset", props: Props{}},
+		{
+			name:  "unknown_values",
+			props: Props{Size: "meduim", Style: "primray", Count: 2, Intent: "anything"},
+			want: []string{
+				`count=2 not in [1]`,
+				`size="meduim" not in [small medium large]`,
+				`style="primray" not in [link primary]`,
+			},
+			wantSlots: []string{
+				`count=2 not in [1]`,
+				`size="meduim" not in [small medium large]`,
+				`style="primray" not in [link primary]`,
+				`variant="anything" not in [danger]`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			classes, err := button.ClassesE(test.props)
+			if want := button.Classes(test.props); classes != want {
+				t.Errorf("got %s, want %s", classes, want)
+			}
+			checkUnknownValues(t, err, test.want)
+
+			slots, err := button.SlotsE(test.props)
+			if got, want := slots.Get("icon"), button.Slots(test.props).Get("icon"); got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+			wantSlots := test.wantSlots
+			if wantSlots == nil {
+				wantSlots = test.want
+			}
+			checkUnknownValues(t, err, wantSlots)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := button.ClassesE(Props{Size: "meduim"})
+		var unknown *UnknownValuesError
+		if !errors.As(err, &unknown) {
+			t.Fatalf("got error %v, want an *UnknownValuesError", err)
+		}
+		if unknown.Component != "button" {
+			t.Errorf("got component %s, want button", unknown.Component)
+		}
+		u := unknown.Values[0]
+		if !strings.HasSuffix(u.File, "strict_test.go") || u.Line == 0 {
+			t.Errorf("got location %s:%d, want strict_test.go", u.File, u.Line)
+		}
+		want := `cva: unknown variant values of component "button": size="meduim" not in`
+		if !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("got %s, want prefix %s", err, want)
+		}
+	})
+}
+
+// checkUnknownValues checks that err lists the given unknown values, ignoring their locations.
+func checkUnknownValues(t *testing.T, err error, want []string) {
+	t.Helper()
+	if len(want) == 0 {
+		if err != nil {
+			t.Errorf("got error %v, want nil", err)
+		}
+		return
+	}
+
+	var unknown *UnknownValuesError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("got error %v, want an *UnknownValuesError", err)
+	}
+	var got []string
+	for _, u := range unknown.Values {
+		u.File = ""
+		got = append(got, u.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+package cva
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Roundaround/cva-go/internal/variantvalue"
+)
+
+// TagVariants holds the variants declared with cva struct tags on the fields of a props struct,
+// as parsed by ParseTags.
+//
+// A field is turned into a variant by a tag of the form
+//
+//	Size string `cva:"size,default=medium,values=small|medium|large"`
+//
+// where the first element is the variant's name, defaulting to the field's name when empty, and
+// the optional default and values elements configure the variant like Variant.WithDefault and
+// Variant.WithValues. Fields without a cva tag, or tagged with "-", are ignored. Tagged fields must
+// be exported and of a bool, string, integer or floating-point kind.
+type TagVariants[P any] struct {
+	variants []*Variant[P, any]
+	types    []reflect.Type
+	byName   map[string]int
+}
+
+// ParseTags reads the cva struct tags of the fields of P, which must be a struct type, creating a
+// Variant for each tagged field. It returns an error describing the first malformed tag found.
+//
+// ParseTags is intended to be called once, during program initialization.
+func ParseTags[P any]() (*TagVariants[P], error) {
+	typ := reflect.TypeFor[P]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cva: ParseTags: %s is not a struct type", typ)
+	}
+
+	t := &TagVariants[P]{byName: make(map[string]int)}
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup("cva")
+		if !ok || tag == "-" {
+			continue
+		}
+		v, err := tagVariant[P](field, tag)
+		if err != nil {
+			return nil, fmt.Errorf("cva: ParseTags: field %s of %s: %w", field.Name, typ, err)
+		}
+		if _, ok := t.byName[v.name]; ok {
+			return nil, fmt.Errorf(
+				"cva: ParseTags: field %s of %s: duplicate variant name %q", field.Name, typ, v.name,
+			)
+		}
+		t.byName[v.name] = len(t.variants)
+		t.variants = append(t.variants, v)
+		t.types = append(t.types, field.Type)
+	}
+	return t, nil
+}
+
+// MustParseTags is like ParseTags, but panics if a tag is malformed.
+func MustParseTags[P any]() *TagVariants[P] {
+	t, err := ParseTags[P]()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// tagVariant creates the variant declared by the cva tag of the given field.
+func tagVariant[P any](field reflect.StructField, tag string) (*Variant[P, any], error) {
+	if !field.IsExported() {
+		return nil, fmt.Errorf("cva tag on unexported field")
+	}
+	if !variantvalue.Supported(field.Type.Kind()) {
+		return nil, fmt.Errorf("unsupported type %s", field.Type)
+	}
+
+	index := field.Index
+	v := NewVariant(func(p P) any {
+		return tagValueOf(reflect.ValueOf(p).FieldByIndex(index))
+	})
+
+	name, rest, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	v.WithName(name)
+
+	for rest != "" {
+		var elem string
+		elem, rest, _ = strings.Cut(rest, ",")
+		key, value, _ := strings.Cut(elem, "=")
+		switch key {
+		case "default":
+			val, err := parseTagValue(field.Type, value)
+			if err != nil {
+				return nil, err
+			}
+			v.WithDefault(val)
+		case "values":
+			var vals []any
+			for _, s := range strings.Split(value, "|") {
+				val, err := parseTagValue(field.Type, s)
+				if err != nil {
+					return nil, err
+				}
+				vals = append(vals, val)
+			}
+			v.WithValues(vals...)
+		default:
+			return nil, fmt.Errorf("unknown cva tag element %q", elem)
+		}
+	}
+	return v, nil
+}
+
+// tagValueOf returns the variant value of a tagged field. The field's zero value is returned as
+// nil, the zero value of the variant's value type, so that defaults apply to it.
+func tagValueOf(v reflect.Value) any {
+	if v.IsZero() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// parseTagValue parses s as a value of the given type, as used in struct tags and by
+// TagVariants.Maps.
+func parseTagValue(typ reflect.Type, s string) (any, error) {
+	v, err := variantvalue.Parse(typ, s)
+	if err != nil {
+		return nil, err
+	}
+	return tagValueOf(v), nil
+}
+
+// Names returns the names of the declared variants, in the order of their fields.
+func (t *TagVariants[P]) Names() []string {
+	names := make([]string, len(t.variants))
+	for i, v := range t.variants {
+		names[i] = v.name
+	}
+	return names
+}
+
+// Variant returns the variant with the given name. It panics if there is none, as Variant is
+// intended to be called while defining components; use Names to list the declared variants.
+//
+// The variant's values have the type of its field, which matters for fields of named types: e.g.
+// values passed to Is must be of type Size rather than string for a field of type Size.
+func (t *TagVariants[P]) Variant(name string) *Variant[P, any] {
+	i, ok := t.byName[name]
+	if !ok {
+		panic(fmt.Sprintf("cva: TagVariants.Variant: %s has no tagged variant named %q",
+			reflect.TypeFor[P](), name))
+	}
+	return t.variants[i]
+}
+
+// Value parses s as a value of the named variant, as written in struct tags.
+func (t *TagVariants[P]) Value(name, s string) (any, error) {
+	i, ok := t.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("cva: no tagged variant named %q", name)
+	}
+	val, err := parseTagValue(t.types[i], s)
+	if err != nil {
+		return nil, fmt.Errorf("cva: variant %q: %w", name, err)
+	}
+	return val, nil
+}
+
+// Maps returns a new Option applying, for each variant named in classes, the class lists of the
+// variant's map like Variant.Map. The maps are keyed by the variants' values as written in struct
+// tags, and applied in the order of the variants' fields.
+//
+// Names of undeclared variants and keys that cannot be parsed as values of their variant are
+// ignored, and reported as problems by NewE.
+func (t *TagVariants[P]) Maps(classes map[string]map[string]string) Option[P] {
+	file, line := callerLocation()
+
+	var problems []string
+	for _, name := range sortedKeys(classes) {
+		if _, ok := t.byName[name]; !ok {
+			problems = append(problems, fmt.Sprintf("TagVariants.Maps: no tagged variant named %q", name))
+		}
+	}
+
+	var opts []Option[P]
+	for i, v := range t.variants {
+		m, ok := classes[v.name]
+		if !ok {
+			continue
+		}
+		typed := make(map[any]string, len(m))
+		for _, s := range sortedKeys(m) {
+			val, err := parseTagValue(t.types[i], s)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("TagVariants.Maps: %s: %s", v.describe(), err))
+				continue
+			}
+			typed[val] = m[s]
+		}
+		opts = append(opts, v.Map(typed))
+	}
+
+	return func(c *Cva[P]) {
+		c.addProblems(file, line, problems...)
+		for _, opt := range opts {
+			opt(c)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package cva
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	type Size string
+	type Props struct {
+		Size     Size   `cva:"size,default=medium,values=small|medium|large"`
+		Disabled bool   `cva:""`
+		Level    int    `cva:"level,values=1|2|3"`
+		Label    string `cva:"-"`
+		Href     string
+	}
+
+	tags, err := ParseTags[Props]()
+	if err != nil {
+		t.Fatalf("got error %v, want nil", err)
+	}
+
+	t.Run("Names", func(t *testing.T) {
+		got := strings.Join(tags.Names(), ",")
+		want := "size,Disabled,level"
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("Variant", func(t *testing.T) {
+		size := tags.Variant("size")
+		if size == nil {
+			t.Fatalf("got nil, want variant")
+		}
+		func() {
+			defer func() {
+				want := `cva: TagVariants.Variant: cva.Props has no tagged variant named "Size"`
+				if got := recover(); got != want {
+					t.Errorf("got %v, want panic %s", got, want)
+				}
+			}()
+			tags.Variant("Size")
+		}()
+		schema := size.schema()
+		if schema.Default != Size("medium") || len(schema.Values) != 3 {
+			t.Errorf("got %+v, want default medium and 3 values", schema)
+		}
+	})
+
+	button := New(
+		Base[Props]("btn"),
+		tags.Maps(map[string]map[string]string{
+			"size":     {"small": "h-9", "medium": "h-10", "large": "h-11"},
+			"Disabled": {"true": "opacity-50"},
+			"level":    {"2": "font-bold"},
+		}),
+		tags.Variant("size").IsNot(Size("small")).Then("rounded-md"),
+	)
+
+	tests := []struct {
+		name  string
+		props Props
+		want  string
+	}{
+		{
+			name:  "defaults",
+			props: Props{},
+			want:  "btn h-10 rounded-md",
+		},
+		{
+			name:  "values",
+			props: Props{Size: "small", Disabled: true, Level: 2},
+			want:  "btn h-9 opacity-50 font-bold",
+		},
+		{
+			name:  "unknown-value",
+			props: Props{Size: "huge", Level: 4},
+			want:  "btn h-10 rounded-md",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := button.Classes(test.props)
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	t.Run("Value", func(t *testing.T) {
+		got, err := tags.Value("level", "2")
+		if err != nil || got != 2 {
+			t.Errorf("got %v, %v, want 2, nil", got, err)
+		}
+		if _, err := tags.Value("level", "two"); err == nil {
+			t.Errorf("got nil error, want invalid value")
+		}
+		if _, err := tags.Value("missing", "2"); err == nil {
+			t.Errorf("got nil error, want unknown variant")
+		}
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		_, err := NewE(tags.Maps(map[string]map[string]string{
+			"size":  {"huge": "h-12"},
+			"level": {"two": "font-bold"},
+			"color": {"red": "text-red-500"},
+		}))
+		var defErr *DefinitionError
+		if !errors.As(err, &defErr) {
+			t.Fatalf("got %v, want *DefinitionError", err)
+		}
+		want := []string{
+			`TagVariants.Maps: no tagged variant named "color"`,
+			`TagVariants.Maps: variant "level": invalid int value "two"`,
+			`Variant.Map: "huge" is not one of the values [small medium large] of variant "size", and is never used`,
+		}
+		if len(defErr.Problems) != len(want) {
+			t.Fatalf("got %v, want %v", defErr.Problems, want)
+		}
+		for i, p := range defErr.Problems {
+			if p.Message != want[i] {
+				t.Errorf("got %s, want %s", p.Message, want[i])
+			}
+		}
+	})
+}
+
+func TestParseTagsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func() error
+		want string
+	}{
+		{
+			name: "not-a-struct",
+			fn: func() error {
+				_, err := ParseTags[string]()
+				return err
+			},
+			want: "cva: ParseTags: string is not a struct type",
+		},
+		{
+			name: "unexported",
+			fn: func() error {
+				type Props struct {
+					size string `cva:"size"`
+				}
+				_, err := ParseTags[Props]()
+				return err
+			},
+			want: "cva tag on unexported field",
+		},
+		{
+			name: "unsupported-type",
+			fn: func() error {
+				type Props struct {
+					Sizes []string `cva:"sizes"`
+				}
+				_, err := ParseTags[Props]()
+				return err
+			},
+			want: "unsupported type []string",
+		},
+		{
+			name: "unknown-element",
+			fn: func() error {
+				type Props struct {
+					Size string `cva:"size,required"`
+				}
+				_, err := ParseTags[Props]()
+				return err
+			},
+			want: `unknown cva tag element "required"`,
+		},
+		{
+			name: "invalid-default",
+			fn: func() error {
+				type Props struct {
+					Level int `cva:"level,default=high"`
+				}
+				_, err := ParseTags[Props]()
+				return err
+			},
+			want: `invalid int value "high"`,
+		},
+		{
+			name: "duplicate-name",
+			fn: func() error {
+				type Props struct {
+					Size  string `cva:"size"`
+					Width string `cva:"size"`
+				}
+				_, err := ParseTags[Props]()
+				return err
+			},
+			want: `duplicate variant name "size"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.fn()
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got %v, want error containing %s", err, test.want)
+			}
+		})
+	}
+}
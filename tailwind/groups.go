@@ -0,0 +1,308 @@
+package tailwind
+
+// Group is a set of utilities setting the same CSS properties, such that only the last utility of
+// the group (for the same modifiers) takes effect.
+//
+// A utility belongs to a group if it is listed in Classes, or if it is made up of one of the
+// Prefixes followed by a dash and a value accepted by Values (e.g. "p" and "4" for "p-4"). A
+// prefix on its own (e.g. "border") is passed to Values as an empty value.
+type Group struct {
+	// ID uniquely identifies the group. Custom groups with the same ID as a built-in group
+	// replace it.
+	ID string
+	// Classes lists the exact utilities belonging to the group.
+	Classes []string
+	// Prefixes lists the utility prefixes belonging to the group.
+	Prefixes []string
+	// Values reports whether a prefixed utility's value belongs to the group. Nil accepts every
+	// non-empty value.
+	Values func(value string) bool
+	// Conflicts lists the IDs of other groups overridden by utilities of this group, e.g. "p"
+	// overrides "px" and "py".
+	Conflicts []string
+}
+
+func exact(id string, classes ...string) Group {
+	return Group{ID: id, Classes: classes}
+}
+
+func prefixed(id string, prefix string, values func(string) bool, conflicts ...string) Group {
+	return Group{ID: id, Prefixes: []string{prefix}, Values: values, Conflicts: conflicts}
+}
+
+// sides generates the groups for a utility with per-side variants, like padding or margin.
+func sides(prefix string, values func(string) bool) []Group {
+	return []Group{
+		prefixed(prefix, prefix, values,
+			prefix+"x", prefix+"y", prefix+"s", prefix+"e",
+			prefix+"t", prefix+"r", prefix+"b", prefix+"l"),
+		prefixed(prefix+"x", prefix+"x", values, prefix+"r", prefix+"l"),
+		prefixed(prefix+"y", prefix+"y", values, prefix+"t", prefix+"b"),
+		prefixed(prefix+"s", prefix+"s", values),
+		prefixed(prefix+"e", prefix+"e", values),
+		prefixed(prefix+"t", prefix+"t", values),
+		prefixed(prefix+"r", prefix+"r", values),
+		prefixed(prefix+"b", prefix+"b", values),
+		prefixed(prefix+"l", prefix+"l", values),
+	}
+}
+
+// borderSides generates the groups for border width and color utilities, which use dashed side
+// names, e.g. "border-x-2" and "border-t-red-500".
+func borderSides(id string, values func(string) bool) []Group {
+	return []Group{
+		prefixed(id, "border", values,
+			id+"-x", id+"-y", id+"-s", id+"-e", id+"-t", id+"-r", id+"-b", id+"-l"),
+		prefixed(id+"-x", "border-x", values, id+"-r", id+"-l"),
+		prefixed(id+"-y", "border-y", values, id+"-t", id+"-b"),
+		prefixed(id+"-s", "border-s", values),
+		prefixed(id+"-e", "border-e", values),
+		prefixed(id+"-t", "border-t", values),
+		prefixed(id+"-r", "border-r", values),
+		prefixed(id+"-b", "border-b", values),
+		prefixed(id+"-l", "border-l", values),
+	}
+}
+
+// radius generates the groups for border radius utilities.
+func radius() []Group {
+	corner := func(id string) Group {
+		return prefixed("rounded-"+id, "rounded-"+id, either(IsEmpty, IsAny))
+	}
+	side := func(id string, corners ...string) Group {
+		g := corner(id)
+		for _, c := range corners {
+			g.Conflicts = append(g.Conflicts, "rounded-"+c)
+		}
+		return g
+	}
+	return []Group{
+		prefixed("rounded", "rounded", either(IsEmpty, IsAny),
+			"rounded-s", "rounded-e", "rounded-t", "rounded-r", "rounded-b", "rounded-l",
+			"rounded-ss", "rounded-se", "rounded-ee", "rounded-es",
+			"rounded-tl", "rounded-tr", "rounded-br", "rounded-bl"),
+		side("s", "ss", "es"),
+		side("e", "se", "ee"),
+		side("t", "tl", "tr"),
+		side("r", "tr", "br"),
+		side("b", "br", "bl"),
+		side("l", "tl", "bl"),
+		corner("ss"), corner("se"), corner("ee"), corner("es"),
+		corner("tl"), corner("tr"), corner("br"), corner("bl"),
+	}
+}
+
+// defaultGroups returns the built-in utility groups, covering the commonly used parts of
+// TailwindCSS. Groups listed earlier take precedence when a utility matches several of them, so
+// color groups, which accept any value, come after the more specific groups sharing a prefix.
+func defaultGroups() []Group {
+	groups := []Group{
+		// Layout
+		exact("display", "block", "inline-block", "inline", "flex", "inline-flex", "table",
+			"inline-table", "table-caption", "table-cell", "table-column", "table-column-group",
+			"table-footer-group", "table-header-group", "table-row-group", "table-row",
+			"flow-root", "grid", "inline-grid", "contents", "list-item", "hidden"),
+		exact("position", "static", "fixed", "absolute", "relative", "sticky"),
+		exact("visibility", "visible", "invisible", "collapse"),
+		exact("sr", "sr-only", "not-sr-only"),
+		exact("box-sizing", "box-border", "box-content"),
+		exact("isolation", "isolate", "isolation-auto"),
+		prefixed("float", "float", IsAny),
+		prefixed("clear", "clear", IsAny),
+		prefixed("aspect", "aspect", IsAny),
+		prefixed("columns", "columns", IsAny),
+		prefixed("object-fit", "object", oneOf("contain", "cover", "fill", "none", "scale-down")),
+		prefixed("object-position", "object", IsAny),
+		prefixed("overflow", "overflow", IsAny, "overflow-x", "overflow-y"),
+		prefixed("overflow-x", "overflow-x", IsAny),
+		prefixed("overflow-y", "overflow-y", IsAny),
+		prefixed("overscroll", "overscroll", IsAny, "overscroll-x", "overscroll-y"),
+		prefixed("overscroll-x", "overscroll-x", IsAny),
+		prefixed("overscroll-y", "overscroll-y", IsAny),
+		prefixed("inset", "inset", IsAny,
+			"inset-x", "inset-y", "start", "end", "top", "right", "bottom", "left"),
+		prefixed("inset-x", "inset-x", IsAny, "right", "left"),
+		prefixed("inset-y", "inset-y", IsAny, "top", "bottom"),
+		prefixed("start", "start", IsAny),
+		prefixed("end", "end", IsAny),
+		prefixed("top", "top", IsAny),
+		prefixed("right", "right", IsAny),
+		prefixed("bottom", "bottom", IsAny),
+		prefixed("left", "left", IsAny),
+		prefixed("z", "z", IsAny),
+
+		// Flexbox & grid
+		prefixed("basis", "basis", IsAny),
+		exact("flex-direction", "flex-row", "flex-row-reverse", "flex-col", "flex-col-reverse"),
+		exact("flex-wrap", "flex-wrap", "flex-wrap-reverse", "flex-nowrap"),
+		prefixed("flex", "flex", IsAny),
+		prefixed("grow", "grow", either(IsEmpty, IsAny)),
+		prefixed("shrink", "shrink", either(IsEmpty, IsAny)),
+		prefixed("order", "order", IsAny),
+		prefixed("grid-cols", "grid-cols", IsAny),
+		prefixed("col-start-end", "col", IsAny),
+		prefixed("grid-rows", "grid-rows", IsAny),
+		prefixed("row-start-end", "row", IsAny),
+		prefixed("grid-flow", "grid-flow", IsAny),
+		prefixed("auto-cols", "auto-cols", IsAny),
+		prefixed("auto-rows", "auto-rows", IsAny),
+		prefixed("gap", "gap", IsAny, "gap-x", "gap-y"),
+		prefixed("gap-x", "gap-x", IsAny),
+		prefixed("gap-y", "gap-y", IsAny),
+		prefixed("justify-items", "justify-items", IsAny),
+		prefixed("justify-self", "justify-self", IsAny),
+		prefixed("justify-content", "justify", IsAny),
+		prefixed("align-content", "content",
+			oneOf("normal", "center", "start", "end", "between", "around", "evenly", "baseline",
+				"stretch")),
+		prefixed("content", "content", IsAny),
+		prefixed("align-items", "items", IsAny),
+		prefixed("align-self", "self", IsAny),
+		prefixed("place-content", "place-content", IsAny),
+		prefixed("place-items", "place-items", IsAny),
+		prefixed("place-self", "place-self", IsAny),
+
+		// Spacing
+		prefixed("space-x", "space-x", IsAny),
+		prefixed("space-y", "space-y", IsAny),
+		exact("space-x-reverse", "space-x-reverse"),
+		exact("space-y-reverse", "space-y-reverse"),
+	}
+	groups = append(groups, sides("p", IsAny)...)
+	groups = append(groups, sides("m", IsAny)...)
+
+	groups = append(groups,
+		// Sizing
+		prefixed("size", "size", IsAny, "w", "h"),
+		prefixed("w", "w", IsAny),
+		prefixed("min-w", "min-w", IsAny),
+		prefixed("max-w", "max-w", IsAny),
+		prefixed("h", "h", IsAny),
+		prefixed("min-h", "min-h", IsAny),
+		prefixed("max-h", "max-h", IsAny),
+
+		// Typography
+		prefixed("font-size", "text", isSize, "leading"),
+		prefixed("text-align", "text", oneOf("left", "center", "right", "justify", "start", "end")),
+		prefixed("text-wrap", "text", oneOf("wrap", "nowrap", "balance", "pretty")),
+		exact("text-overflow", "truncate", "text-ellipsis", "text-clip"),
+		prefixed("text-color", "text", IsAny),
+		prefixed("font-weight", "font",
+			either(IsNumber, oneOf("thin", "extralight", "light", "normal", "medium", "semibold",
+				"bold", "extrabold", "black"))),
+		prefixed("font-family", "font", IsAny),
+		exact("font-style", "italic", "not-italic"),
+		exact("font-smoothing", "antialiased", "subpixel-antialiased"),
+		exact("text-decoration", "underline", "overline", "line-through", "no-underline"),
+		exact("text-transform", "uppercase", "lowercase", "capitalize", "normal-case"),
+		prefixed("decoration-style", "decoration",
+			oneOf("solid", "double", "dotted", "dashed", "wavy")),
+		prefixed("decoration-thickness", "decoration", either(IsNumber, oneOf("auto", "from-font"))),
+		prefixed("decoration-color", "decoration", IsAny),
+		prefixed("underline-offset", "underline-offset", IsAny),
+		prefixed("leading", "leading", IsAny),
+		prefixed("tracking", "tracking", IsAny),
+		prefixed("indent", "indent", IsAny),
+		prefixed("align", "align", IsAny),
+		prefixed("whitespace", "whitespace", IsAny),
+		prefixed("break", "break", IsAny),
+		prefixed("line-clamp", "line-clamp", IsAny),
+		prefixed("list-type", "list", oneOf("none", "disc", "decimal")),
+		prefixed("list-position", "list", oneOf("inside", "outside")),
+
+		// Backgrounds
+		prefixed("bg-attachment", "bg", oneOf("fixed", "local", "scroll")),
+		prefixed("bg-clip", "bg-clip", IsAny),
+		prefixed("bg-origin", "bg-origin", IsAny),
+		prefixed("bg-size", "bg", oneOf("auto", "cover", "contain")),
+		prefixed("bg-repeat", "bg", oneOf("repeat", "no-repeat", "repeat-x", "repeat-y",
+			"repeat-round", "repeat-space")),
+		prefixed("bg-position", "bg", oneOf("bottom", "center", "left", "left-bottom",
+			"left-top", "right", "right-bottom", "right-top", "top")),
+		Group{ID: "bg-image", Classes: []string{"bg-none"},
+			Prefixes: []string{"bg-gradient-to", "bg-linear-to", "bg-radial", "bg-conic"},
+			Values:   IsAny},
+		prefixed("bg-image", "bg", isArbitraryImage),
+		prefixed("bg-color", "bg", IsAny),
+		prefixed("gradient-from", "from", IsAny),
+		prefixed("gradient-via", "via", IsAny),
+		prefixed("gradient-to", "to", IsAny),
+	)
+
+	// Borders
+	groups = append(groups, radius()...)
+	groups = append(groups, borderSides("border-width", isWidth)...)
+	groups = append(groups,
+		prefixed("border-style", "border",
+			oneOf("solid", "dashed", "dotted", "double", "hidden", "none")),
+	)
+	groups = append(groups, borderSides("border-color", IsAny)...)
+	groups = append(groups,
+		prefixed("divide-x", "divide-x", isWidth),
+		prefixed("divide-y", "divide-y", isWidth),
+		exact("divide-x-reverse", "divide-x-reverse"),
+		exact("divide-y-reverse", "divide-y-reverse"),
+		prefixed("divide-style", "divide", oneOf("solid", "dashed", "dotted", "double", "none")),
+		prefixed("divide-color", "divide", IsAny),
+		prefixed("outline-style", "outline", either(IsEmpty,
+			oneOf("none", "solid", "dashed", "dotted", "double", "hidden"))),
+		prefixed("outline-width", "outline", isWidth),
+		prefixed("outline-offset", "outline-offset", IsAny),
+		prefixed("outline-color", "outline", IsAny),
+		exact("ring-inset", "ring-inset"),
+		prefixed("ring-width", "ring", isWidth),
+		prefixed("ring-offset-width", "ring-offset", isWidth),
+		prefixed("ring-offset-color", "ring-offset", IsAny),
+		prefixed("ring-color", "ring", IsAny),
+
+		// Effects
+		prefixed("shadow", "shadow", either(IsEmpty, isSize, oneOf("inner", "none"))),
+		prefixed("shadow-color", "shadow", IsAny),
+		prefixed("opacity", "opacity", IsAny),
+		prefixed("mix-blend", "mix-blend", IsAny),
+		prefixed("blur", "blur", either(IsEmpty, IsAny)),
+		prefixed("backdrop-blur", "backdrop-blur", either(IsEmpty, IsAny)),
+
+		// Transitions & animation
+		prefixed("transition", "transition", either(IsEmpty, IsAny)),
+		prefixed("duration", "duration", IsAny),
+		prefixed("ease", "ease", IsAny),
+		prefixed("delay", "delay", IsAny),
+		prefixed("animate", "animate", IsAny),
+
+		// Transforms
+		prefixed("scale", "scale", IsAny, "scale-x", "scale-y"),
+		prefixed("scale-x", "scale-x", IsAny),
+		prefixed("scale-y", "scale-y", IsAny),
+		prefixed("rotate", "rotate", IsAny),
+		prefixed("translate-x", "translate-x", IsAny),
+		prefixed("translate-y", "translate-y", IsAny),
+		prefixed("skew-x", "skew-x", IsAny),
+		prefixed("skew-y", "skew-y", IsAny),
+		prefixed("origin", "origin", IsAny),
+
+		// Interactivity
+		prefixed("cursor", "cursor", IsAny),
+		prefixed("pointer-events", "pointer-events", IsAny),
+		prefixed("select", "select", IsAny),
+		prefixed("resize", "resize", either(IsEmpty, IsAny)),
+		prefixed("scroll-behavior", "scroll", oneOf("auto", "smooth")),
+		prefixed("touch", "touch", IsAny),
+		prefixed("will-change", "will-change", IsAny),
+		prefixed("appearance", "appearance", IsAny),
+		prefixed("accent", "accent", IsAny),
+		prefixed("caret", "caret", IsAny),
+
+		// SVG
+		prefixed("fill", "fill", IsAny),
+		prefixed("stroke-width", "stroke", either(IsNumber, isArbitraryLengthValue)),
+		prefixed("stroke", "stroke", IsAny),
+	)
+
+	return groups
+}
+
+// isArbitraryLengthValue accepts arbitrary values holding a length.
+func isArbitraryLengthValue(value string) bool {
+	return IsArbitrary(value) && isArbitraryLength(value)
+}
@@ -0,0 +1,259 @@
+// Package tailwind resolves conflicts between TailwindCSS utility classes.
+//
+// A Merger understands which utilities set the same CSS properties, such that later utilities
+// override earlier ones, e.g. "px-4 px-2" is merged into "px-2" and "p-4 px-2" is left untouched
+// while "px-2 p-4" is merged into "p-4". Conflicts are only resolved between utilities with the
+// same modifiers (e.g. "hover:" or "md:") and importance. Classes which are not recognised as
+// Tailwind utilities are always kept.
+//
+// A Merger satisfies cva.Merger, and can be used with cva.WithMerger or cva.SetDefaultMerger.
+package tailwind
+
+import (
+	"slices"
+	"strings"
+)
+
+// Config configures a Merger.
+type Config struct {
+	// Prefix is the prefix configured for TailwindCSS utilities, if any. A prefix ending with a
+	// colon (e.g. "tw:") is treated as a TailwindCSS v4 variant-style prefix, as in "tw:hover:p-4".
+	// Otherwise it is treated as a TailwindCSS v3 prefix, as in "hover:tw-p-4". Classes without
+	// the prefix are not recognised as utilities and are always kept.
+	Prefix string
+	// Groups lists custom utility groups. They take precedence over the built-in groups when a
+	// utility matches several groups, and replace built-in groups with the same ID.
+	Groups []Group
+}
+
+// Merger merges lists of TailwindCSS classes, resolving conflicts between utilities. It is safe
+// for concurrent use.
+type Merger struct {
+	prefix   string
+	variant  bool
+	groups   []Group
+	exact    map[string]int
+	prefixes map[string][]int
+}
+
+// New returns a Merger using the built-in utility groups extended with the given configuration.
+func New(config Config) *Merger {
+	m := &Merger{
+		prefix:   config.Prefix,
+		exact:    make(map[string]int),
+		prefixes: make(map[string][]int),
+	}
+	if p, ok := strings.CutSuffix(config.Prefix, ":"); ok {
+		m.prefix, m.variant = p, true
+	}
+
+	custom := make(map[string]bool, len(config.Groups))
+	for _, g := range config.Groups {
+		custom[g.ID] = true
+	}
+	m.groups = slices.Clone(config.Groups)
+	for _, g := range defaultGroups() {
+		if !custom[g.ID] {
+			m.groups = append(m.groups, g)
+		}
+	}
+
+	for i, g := range m.groups {
+		for _, class := range g.Classes {
+			if _, ok := m.exact[class]; !ok {
+				m.exact[class] = i
+			}
+		}
+		for _, prefix := range g.Prefixes {
+			m.prefixes[prefix] = append(m.prefixes[prefix], i)
+		}
+	}
+	return m
+}
+
+var defaultMerger = New(Config{})
+
+// Merge merges the given class lists using a Merger with the default configuration.
+func Merge(classes ...string) string {
+	return defaultMerger.Merge(classes...)
+}
+
+// Merge joins the given class lists, removing utilities overridden by later utilities of the same
+// group, or of a group conflicting with it, having the same modifiers and importance.
+func (m *Merger) Merge(classes ...string) string {
+	var tokens []string
+	for _, c := range classes {
+		tokens = append(tokens, strings.Fields(c)...)
+	}
+
+	claimed := make(map[string]bool)
+	keep := make([]bool, len(tokens))
+	for i := len(tokens) - 1; i >= 0; i-- {
+		key, groups, ok := m.parse(tokens[i])
+		if !ok {
+			keep[i] = true
+			continue
+		}
+		if claimed[key+groups[0]] {
+			continue
+		}
+		keep[i] = true
+		for _, g := range groups {
+			claimed[key+g] = true
+		}
+	}
+
+	var b strings.Builder
+	for i, token := range tokens {
+		if !keep[i] {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(token)
+	}
+	return b.String()
+}
+
+// parse splits a class into the key identifying its modifiers and importance, and the IDs of the
+// group it belongs to followed by the groups it conflicts with. It reports false if the class is
+// not a recognised utility.
+func (m *Merger) parse(class string) (string, []string, bool) {
+	modifiers := splitModifiers(class)
+	utility := modifiers[len(modifiers)-1]
+	modifiers = modifiers[:len(modifiers)-1]
+
+	if m.variant {
+		if len(modifiers) == 0 || modifiers[0] != m.prefix {
+			return "", nil, false
+		}
+		modifiers = modifiers[1:]
+	}
+
+	important := false
+	if u, ok := strings.CutPrefix(utility, "!"); ok {
+		utility, important = u, true
+	} else if u, ok := strings.CutSuffix(utility, "!"); ok {
+		utility, important = u, true
+	}
+
+	if IsArbitrary(utility) {
+		prop, _, ok := strings.Cut(utility[1:len(utility)-1], ":")
+		if !ok || prop == "" {
+			return "", nil, false
+		}
+		return modifierKey(modifiers, important), []string{"[" + prop + "]"}, true
+	}
+
+	utility = strings.TrimPrefix(utility, "-")
+	if !m.variant && m.prefix != "" {
+		u, ok := strings.CutPrefix(utility, m.prefix)
+		if !ok {
+			return "", nil, false
+		}
+		utility = u
+	}
+
+	g, ok := m.group(utility)
+	if !ok {
+		return "", nil, false
+	}
+	return modifierKey(modifiers, important), append([]string{g.ID}, g.Conflicts...), true
+}
+
+// group returns the group the utility belongs to, trying exact classes first and then prefixes
+// from longest to shortest.
+func (m *Merger) group(utility string) (Group, bool) {
+	if i, ok := m.exact[utility]; ok {
+		return m.groups[i], true
+	}
+
+	utility = stripPostfix(utility)
+	if i, ok := m.exact[utility]; ok {
+		return m.groups[i], true
+	}
+
+	// Only dashes before any arbitrary value can separate the prefix from the value.
+	end := len(utility)
+	if i := strings.IndexByte(utility, '['); i >= 0 {
+		end = i
+	}
+	for i := end; i > 0; i = strings.LastIndexByte(utility[:i], '-') {
+		prefix, value := utility[:i], ""
+		if i < len(utility) {
+			value = utility[i+1:]
+		}
+		for _, gi := range m.prefixes[prefix] {
+			g := m.groups[gi]
+			if g.Values == nil && IsAny(value) || g.Values != nil && g.Values(value) {
+				return g, true
+			}
+		}
+	}
+	return Group{}, false
+}
+
+// splitModifiers splits a class on the colons separating its modifiers, ignoring colons within
+// brackets and parentheses.
+func splitModifiers(class string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(class); i++ {
+		switch class[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				parts = append(parts, class[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, class[start:])
+}
+
+// stripPostfix removes a trailing postfix modifier, such as the opacity in "bg-red-500/50" or the
+// line height in "text-lg/7", unless it is part of a fraction like "w-1/2".
+func stripPostfix(utility string) string {
+	i := strings.LastIndexByte(utility, '/')
+	if i < 0 || strings.IndexByte(utility[i:], ']') >= 0 {
+		return utility
+	}
+	// Keep fractions, whose numerator is a number.
+	j := strings.LastIndexByte(utility[:i], '-')
+	if j >= 0 && IsNumber(utility[j+1:i]) && IsNumber(utility[i+1:]) {
+		return utility
+	}
+	return utility[:i]
+}
+
+// modifierKey returns a key identifying the given modifiers and importance. The order of
+// modifiers is not significant, except around arbitrary variants like "[&>*]", whose position is
+// preserved.
+func modifierKey(modifiers []string, important bool) string {
+	sorted := make([]string, 0, len(modifiers))
+	run := 0
+	for _, mod := range modifiers {
+		if strings.HasPrefix(mod, "[") {
+			slices.Sort(sorted[run:])
+			sorted = append(sorted, mod)
+			run = len(sorted)
+			continue
+		}
+		sorted = append(sorted, mod)
+	}
+	slices.Sort(sorted[run:])
+
+	var b strings.Builder
+	for _, mod := range sorted {
+		b.WriteString(mod)
+		b.WriteByte(':')
+	}
+	if important {
+		b.WriteByte('!')
+	}
+	return b.String()
+}
@@ -0,0 +1,212 @@
+package tailwind
+
+import (
+	"testing"
+
+	"github.com/Roundaround/cva-go"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []string
+		want    string
+	}{
+		{
+			name:    "empty",
+			classes: []string{"", "  "},
+			want:    "",
+		},
+		{
+			name:    "same_group",
+			classes: []string{"px-4 py-2", "px-2"},
+			want:    "py-2 px-2",
+		},
+		{
+			name:    "conflicting_groups",
+			classes: []string{"px-2 py-1 p-4"},
+			want:    "p-4",
+		},
+		{
+			name:    "refinement",
+			classes: []string{"p-4 px-2"},
+			want:    "p-4 px-2",
+		},
+		{
+			name:    "unknown",
+			classes: []string{"btn p-2 btn-primary p-4 btn"},
+			want:    "btn btn-primary p-4 btn",
+		},
+		{
+			name:    "modifiers",
+			classes: []string{"hover:bg-red-500 bg-blue-500 hover:bg-green-500 md:bg-white"},
+			want:    "bg-blue-500 hover:bg-green-500 md:bg-white",
+		},
+		{
+			name:    "modifier_order",
+			classes: []string{"hover:md:p-2 md:hover:p-4"},
+			want:    "md:hover:p-4",
+		},
+		{
+			name:    "arbitrary_variant_order",
+			classes: []string{"[&>*]:hover:p-2 hover:[&>*]:p-4"},
+			want:    "[&>*]:hover:p-2 hover:[&>*]:p-4",
+		},
+		{
+			name:    "group_modifiers",
+			classes: []string{"group-hover:text-red-500 group-hover:text-blue-500 text-white"},
+			want:    "group-hover:text-blue-500 text-white",
+		},
+		{
+			name:    "important",
+			classes: []string{"!p-2 p-4 !p-6 p-8! px-2"},
+			want:    "p-4 p-8! px-2",
+		},
+		{
+			name:    "negative",
+			classes: []string{"-mt-2 mt-4", "-inset-x-1 left-0"},
+			want:    "mt-4 -inset-x-1 left-0",
+		},
+		{
+			name:    "arbitrary_values",
+			classes: []string{"w-[calc(100%-1rem)] w-1/2", "text-[14px] text-[#fff] text-lg"},
+			want:    "w-1/2 text-[#fff] text-lg",
+		},
+		{
+			name:    "arbitrary_properties",
+			classes: []string{"[mask-type:luminance] hover:[mask-type:alpha] [mask-type:alpha]"},
+			want:    "hover:[mask-type:alpha] [mask-type:alpha]",
+		},
+		{
+			name:    "text_size_and_color",
+			classes: []string{"text-sm text-red-500 text-lg text-blue-500/50 text-center"},
+			want:    "text-lg text-blue-500/50 text-center",
+		},
+		{
+			name:    "font_size_overrides_leading",
+			classes: []string{"leading-6 text-lg/7 leading-tight"},
+			want:    "text-lg/7 leading-tight",
+		},
+		{
+			name:    "borders",
+			classes: []string{"border border-2 border-x-4 border-red-500 border-t-blue-500 border-dashed"},
+			want:    "border-2 border-x-4 border-red-500 border-t-blue-500 border-dashed",
+		},
+		{
+			name:    "border_sides",
+			classes: []string{"border-l-2 border-x-4 border-t-red-500 border-blue-500"},
+			want:    "border-x-4 border-blue-500",
+		},
+		{
+			name:    "rounded",
+			classes: []string{"rounded-tl-lg rounded-t rounded-md rounded-b-none rounded-br"},
+			want:    "rounded-md rounded-b-none rounded-br",
+		},
+		{
+			name:    "display",
+			classes: []string{"block flex hidden", "flex-col flex-1 flex-wrap"},
+			want:    "hidden flex-col flex-1 flex-wrap",
+		},
+		{
+			name:    "ring_and_shadow",
+			classes: []string{"ring ring-2 ring-red-500 shadow shadow-lg shadow-red-500/50"},
+			want:    "ring-2 ring-red-500 shadow-lg shadow-red-500/50",
+		},
+		{
+			name:    "size",
+			classes: []string{"w-4 h-4 size-8", "h-10"},
+			want:    "size-8 h-10",
+		},
+		{
+			name:    "backgrounds",
+			classes: []string{"bg-[url(/a.png)] bg-red-500 bg-cover bg-[#fff] bg-none"},
+			want:    "bg-cover bg-[#fff] bg-none",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Merge(test.classes...)
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		classes string
+		want    string
+	}{
+		{
+			name:    "v3_prefix",
+			config:  Config{Prefix: "tw-"},
+			classes: "tw-p-2 hover:tw-p-2 p-4 -tw-mt-2 tw-mt-4 hover:tw-p-4 !tw-p-1",
+			want:    "tw-p-2 p-4 tw-mt-4 hover:tw-p-4 !tw-p-1",
+		},
+		{
+			name:    "v4_prefix",
+			config:  Config{Prefix: "tw:"},
+			classes: "tw:p-2 tw:hover:p-2 p-4 tw:p-6 tw:hover:p-4",
+			want:    "p-4 tw:p-6 tw:hover:p-4",
+		},
+		{
+			name: "custom_group",
+			config: Config{Groups: []Group{
+				{ID: "btn-size", Prefixes: []string{"btn"}, Values: IsTshirtSize},
+			}},
+			classes: "btn btn-sm btn-primary btn-lg",
+			want:    "btn btn-primary btn-lg",
+		},
+		{
+			name: "custom_group_precedence",
+			config: Config{Groups: []Group{
+				{ID: "text-shadow", Prefixes: []string{"text-shadow"}},
+				{ID: "text-brand", Classes: []string{"text-brand", "text-brand-dark"}},
+			}},
+			classes: "text-shadow-sm text-red-500 text-shadow-lg text-brand text-brand-dark",
+			want:    "text-red-500 text-shadow-lg text-brand-dark",
+		},
+		{
+			name: "replaced_group",
+			config: Config{Groups: []Group{
+				{ID: "p", Prefixes: []string{"p"}, Values: IsAny},
+			}},
+			classes: "px-2 p-4 py-2 px-6 px-8",
+			want:    "p-4 py-2 px-8",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := New(test.config).Merge(test.classes)
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWithMerger(t *testing.T) {
+	type Props struct {
+		Size string
+	}
+
+	button := cva.New(
+		cva.WithMerger[Props](New(Config{})),
+		cva.Base[Props]("inline-flex px-4 py-2 rounded"),
+		cva.MapVariant(
+			func(p Props) string { return p.Size },
+			map[string]string{"small": "px-2 py-1 rounded-sm", "large": "px-6"},
+		),
+	)
+
+	got := button.Classes(Props{Size: "small"})
+	want := "inline-flex px-2 py-1 rounded-sm"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
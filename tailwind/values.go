@@ -0,0 +1,124 @@
+package tailwind
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	numberRe    = regexp.MustCompile(`^\d+(\.\d+)?$`)
+	fractionRe  = regexp.MustCompile(`^\d+/\d+$`)
+	tshirtRe    = regexp.MustCompile(`^(\d+(\.\d+)?)?(xs|sm|md|lg|xl)$`)
+	lengthRe    = regexp.MustCompile(`^-?\d*\.?\d+(%|px|r?em|[sdl]?v[hw]|v(min|max)|ch|ex|cm|mm|in|pt|pc|fr)$`)
+	colorFuncRe = regexp.MustCompile(`^(rgba?|hsla?|hwb|(ok)?(lab|lch)|color-mix)\(`)
+)
+
+// lengthKeywords are the non-numeric values accepted by Tailwind's sizing and spacing scales.
+var lengthKeywords = []string{
+	"px", "full", "screen", "auto", "min", "max", "fit", "svh", "lvh", "dvh", "svw", "lvw", "dvw",
+	"prose",
+}
+
+// IsAny accepts any value, except the empty value of a bare utility such as "border".
+func IsAny(value string) bool {
+	return value != ""
+}
+
+// IsEmpty accepts only the empty value of a bare utility such as "border" or "rounded".
+func IsEmpty(value string) bool {
+	return value == ""
+}
+
+// IsNumber accepts integer and decimal numbers, e.g. "2" or "0.5".
+func IsNumber(value string) bool {
+	return numberRe.MatchString(value)
+}
+
+// IsArbitrary accepts arbitrary values such as "[3px]" or "[#fff]".
+func IsArbitrary(value string) bool {
+	return strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]")
+}
+
+// IsLength accepts values from Tailwind's sizing and spacing scales (numbers, fractions, t-shirt
+// sizes and keywords like "full" or "screen"), as well as arbitrary values that are not colors.
+func IsLength(value string) bool {
+	if IsArbitrary(value) {
+		return !isArbitraryColor(value)
+	}
+	return IsNumber(value) || fractionRe.MatchString(value) || tshirtRe.MatchString(value) ||
+		slices.Contains(lengthKeywords, value)
+}
+
+// IsTshirtSize accepts t-shirt sizes such as "sm", "lg" or "2xl".
+func IsTshirtSize(value string) bool {
+	return tshirtRe.MatchString(value)
+}
+
+// isSize accepts t-shirt sizes and arbitrary lengths, as used by text, shadow and similar
+// utilities whose non-size values are colors.
+func isSize(value string) bool {
+	if IsArbitrary(value) {
+		return isArbitraryLength(value)
+	}
+	return value == "base" || IsTshirtSize(value)
+}
+
+// isWidth accepts the values of border, ring, outline and similar width utilities.
+func isWidth(value string) bool {
+	if IsArbitrary(value) {
+		return isArbitraryLength(value)
+	}
+	return value == "" || IsNumber(value) || value == "px"
+}
+
+// isArbitraryLength accepts arbitrary values explicitly or evidently holding a length.
+func isArbitraryLength(value string) bool {
+	inner := value[1 : len(value)-1]
+	if hint, _, ok := strings.Cut(inner, ":"); ok {
+		return hint == "length" || hint == "number" || hint == "percentage"
+	}
+	return lengthRe.MatchString(inner) || IsNumber(inner) ||
+		strings.HasPrefix(inner, "calc(") || strings.HasPrefix(inner, "min(") ||
+		strings.HasPrefix(inner, "max(") || strings.HasPrefix(inner, "clamp(")
+}
+
+// isArbitraryColor accepts arbitrary values explicitly or evidently holding a color.
+func isArbitraryColor(value string) bool {
+	inner := value[1 : len(value)-1]
+	if hint, _, ok := strings.Cut(inner, ":"); ok {
+		return hint == "color"
+	}
+	return strings.HasPrefix(inner, "#") || colorFuncRe.MatchString(inner)
+}
+
+// isArbitraryImage accepts arbitrary values explicitly or evidently holding an image.
+func isArbitraryImage(value string) bool {
+	if !IsArbitrary(value) {
+		return false
+	}
+	inner := value[1 : len(value)-1]
+	if hint, _, ok := strings.Cut(inner, ":"); ok {
+		return hint == "image" || hint == "url"
+	}
+	return strings.HasPrefix(inner, "url(") || strings.Contains(inner, "gradient(")
+}
+
+// oneOf accepts any of the given values.
+func oneOf(values ...string) func(string) bool {
+	return func(value string) bool {
+		return slices.Contains(values, value)
+	}
+}
+
+// either accepts values accepted by any of the given validators.
+func either(validators ...func(string) bool) func(string) bool {
+	return func(value string) bool {
+		for _, v := range validators {
+			if v(value) {
+				return true
+			}
+		}
+		return false
+	}
+}
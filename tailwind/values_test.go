@@ -0,0 +1,35 @@
+package tailwind
+
+import "testing"
+
+func TestValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    func(string) bool
+		value string
+		want  bool
+	}{
+		{name: "any", fn: IsAny, value: "red-500", want: true},
+		{name: "any_empty", fn: IsAny, value: "", want: false},
+		{name: "empty", fn: IsEmpty, value: "", want: true},
+		{name: "number", fn: IsNumber, value: "0.5", want: true},
+		{name: "number_keyword", fn: IsNumber, value: "px", want: false},
+		{name: "arbitrary", fn: IsArbitrary, value: "[3px]", want: true},
+		{name: "arbitrary_unclosed", fn: IsArbitrary, value: "[3px", want: false},
+		{name: "length_fraction", fn: IsLength, value: "1/2", want: true},
+		{name: "length_keyword", fn: IsLength, value: "screen", want: true},
+		{name: "length_arbitrary", fn: IsLength, value: "[calc(100%-1rem)]", want: true},
+		{name: "length_color", fn: IsLength, value: "[#fff]", want: false},
+		{name: "length_hinted_color", fn: IsLength, value: "[color:var(--c)]", want: false},
+		{name: "tshirt", fn: IsTshirtSize, value: "2xl", want: true},
+		{name: "tshirt_color", fn: IsTshirtSize, value: "red-500", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.fn(test.value); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
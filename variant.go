@@ -1,17 +1,75 @@
 package cva
 
 import (
+	"fmt"
+	"reflect"
 	"slices"
+	"sync/atomic"
 )
 
 // Matcher is a chainable predicate function that can be used to match against a property.
+//
+// Matchers derived from a Variant read the variant's configuration when they are evaluated, so
+// configuring the variant after deriving matchers from it, but before creating a component with
+// them, affects them too.
 type Matcher[P any] struct {
-	fn func(p P) bool
+	fn       func(p P) bool
+	cond     func() Condition
+	validate func(p P) []UnknownValue
+	problems func() []string
+	// use, if set, freezes the configuration of the variants the matcher reads, once an option
+	// derived from it is applied to a component.
+	use func()
+}
+
+// Condition returns a description of the matcher's logic.
+func (m Matcher[P]) Condition() Condition {
+	if m.cond == nil {
+		return Condition{Op: OpFunc}
+	}
+	return m.cond()
+}
+
+// combine creates a matcher from the given matchers, matching according to fn and described by an
+// OpAnd or OpOr condition.
+func combine[P any](op Op, matchers []Matcher[P], fn func(p P) bool) Matcher[P] {
+	validators := make([]func(P) []UnknownValue, len(matchers))
+	for i, m := range matchers {
+		validators[i] = m.validate
+	}
+
+	return Matcher[P]{
+		fn: fn,
+		cond: func() Condition {
+			operands := make([]Condition, len(matchers))
+			for i, m := range matchers {
+				operands[i] = m.Condition()
+			}
+			return Condition{Op: op, Operands: operands}
+		},
+		validate: combineValidators(validators...),
+		problems: func() []string {
+			var problems []string
+			for _, m := range matchers {
+				if m.problems != nil {
+					problems = append(problems, m.problems()...)
+				}
+			}
+			return problems
+		},
+		use: func() {
+			for _, m := range matchers {
+				if m.use != nil {
+					m.use()
+				}
+			}
+		},
+	}
 }
 
 // Or returns a new Matcher that matches if any of the given matchers match.
 func (m Matcher[P]) Or(others ...Matcher[P]) Matcher[P] {
-	return Matcher[P]{func(p P) bool {
+	return combine(OpOr, append([]Matcher[P]{m}, others...), func(p P) bool {
 		if m.fn(p) {
 			return true
 		}
@@ -21,12 +79,12 @@ func (m Matcher[P]) Or(others ...Matcher[P]) Matcher[P] {
 			}
 		}
 		return false
-	}}
+	})
 }
 
 // And returns a new Matcher that matches if all of the given matchers match.
 func (m Matcher[P]) And(others ...Matcher[P]) Matcher[P] {
-	return Matcher[P]{func(p P) bool {
+	return combine(OpAnd, append([]Matcher[P]{m}, others...), func(p P) bool {
 		if !m.fn(p) {
 			return false
 		}
@@ -36,50 +94,121 @@ func (m Matcher[P]) And(others ...Matcher[P]) Matcher[P] {
 			}
 		}
 		return true
-	}}
+	})
 }
 
 // Not returns a new Matcher that matches if the original matcher does not match.
 func (m Matcher[P]) Not() Matcher[P] {
-	return Matcher[P]{func(p P) bool {
-		return !m.fn(p)
-	}}
+	return Matcher[P]{
+		fn: func(p P) bool {
+			return !m.fn(p)
+		},
+		cond: func() Condition {
+			return Condition{Op: OpNot, Operands: []Condition{m.Condition()}}
+		},
+		validate: m.validate,
+		problems: m.problems,
+		use:      m.use,
+	}
 }
 
 // Then returns a new Option that applies the given classes if the matcher matches.
 func (m Matcher[P]) Then(classes ...string) Option[P] {
-	return PredicateVariant(m.fn, classes...)
+	return predicateOption(m.Condition, m.fn, m.validate, classes, m.problems, m.use)
 }
 
 // NewVariant creates a new Variant that can be used to create Cva Options.
 func NewVariant[P any, V comparable](getter func(p P) V) *Variant[P, V] {
-	var defaultVal V
-	return &Variant[P, V]{getter, defaultVal, false, nil}
+	return &Variant[P, V]{getter: getter, id: nextVariantID()}
 }
 
 // Variant is a helper struct that can be used to create Cva Options with its Matcher-producing
 // methods like Test, Is, In, IsNot, and NotIn.
+//
+// The variant's configuration, set with WithName, WithDefault and WithValues, is read by every
+// Matcher and Option derived from it, regardless of the order in which the variant was configured
+// and its matchers derived. The configuration is frozen once a component is created with an option
+// derived from the variant: configuring it afterwards panics, so that the component's class lists,
+// Schema, definition problems, caches and compiled tables all agree on a single configuration.
 type Variant[P any, V comparable] struct {
 	getter     func(p P) V
 	defaultVal V
 	hasDefault bool
 	values     []V
+	name       string
+	id         uint64
+	used       atomic.Bool
 }
 
-// WithDefault sets the default value for the variant.
+// WithName sets the name of the variant, used to identify it in the Schema of components using it.
+// It panics if a component using the variant was already created.
+func (v *Variant[P, V]) WithName(name string) *Variant[P, V] {
+	v.configure("WithName")
+	v.name = name
+	return v
+}
+
+// WithDefault sets the default value for the variant. It panics if a component using the variant
+// was already created.
 func (v *Variant[P, V]) WithDefault(val V) *Variant[P, V] {
+	v.configure("WithDefault")
 	v.defaultVal = val
 	v.hasDefault = true
 	return v
 }
 
-// WithValues sets the values for the variant.
+// WithValues sets the values for the variant. It panics if a component using the variant was
+// already created.
 func (v *Variant[P, V]) WithValues(vals ...V) *Variant[P, V] {
-	v.values = vals
+	v.configure("WithValues")
+	v.values = slices.Clone(vals)
 	return v
 }
 
-func (v Variant[P, V]) get(p P) V {
+// use freezes the variant's configuration, as an option derived from it is applied to a component.
+func (v *Variant[P, V]) use() {
+	v.used.Store(true)
+}
+
+// configure panics if the variant's configuration is frozen, naming the configuring method.
+func (v *Variant[P, V]) configure(method string) {
+	if v.used.Load() {
+		panic(fmt.Sprintf("cva: Variant.%s called on %s after a component using it was created",
+			method, v.describe()))
+	}
+}
+
+func (v *Variant[P, V]) schema() VariantSchema {
+	schema := VariantSchema{
+		Name:       v.name,
+		Values:     toAny(v.values),
+		HasDefault: v.hasDefault,
+		id:         v.id,
+		typ:        reflect.TypeFor[V](),
+		declared:   v.values != nil,
+	}
+	if v.hasDefault {
+		schema.Default = v.defaultVal
+	}
+	return schema
+}
+
+// unknownValues reports the variant's value when it is outside of the values set with WithValues,
+// if any.
+func (v *Variant[P, V]) unknownValues(p P) []UnknownValue {
+	return v.unknownValuesIn(p, v.values)
+}
+
+// unknownValuesIn reports the variant's value when it is outside of the given known values, if
+// any.
+func (v *Variant[P, V]) unknownValuesIn(p P, known []V) []UnknownValue {
+	if check := checkKnown(v.getter, known, v.name, v.id); check != nil {
+		return check(p)
+	}
+	return nil
+}
+
+func (v *Variant[P, V]) get(p P) V {
 	var zero V
 	val := v.getter(p)
 
@@ -93,45 +222,84 @@ func (v Variant[P, V]) get(p P) V {
 	return v.defaultVal
 }
 
+// matcher creates a Matcher from the variant, described by the condition returned by cond and
+// using the given values in the definition problems reported by NewE.
+func (v *Variant[P, V]) matcher(
+	method string,
+	cond func() Condition,
+	fn func(p P) bool,
+	vals ...V,
+) Matcher[P] {
+	return Matcher[P]{
+		fn:       fn,
+		cond:     cond,
+		validate: v.unknownValues,
+		problems: func() []string { return v.problems(method, vals...) },
+		use:      v.use,
+	}
+}
+
 // Test returns a new Matcher that matches if the variant value matches the given predicate function.
-func (v Variant[P, V]) Test(fn func(V) bool) Matcher[P] {
-	return Matcher[P]{func(p P) bool {
+func (v *Variant[P, V]) Test(fn func(V) bool) Matcher[P] {
+	m := v.matcher("Test", func() Condition {
+		return Condition{Op: OpTest, Variant: v.schema()}
+	}, func(p P) bool {
 		return fn(v.get(p))
-	}}
+	})
+	if fn == nil {
+		m.problems = func() []string {
+			return append(v.problems("Test"), nilProblem("Variant.Test", "test function", true)...)
+		}
+	}
+	return m
 }
 
 // Is returns a new Matcher that matches if the variant value is equal to the given value.
-func (v Variant[P, V]) Is(val V) Matcher[P] {
-	return Matcher[P]{func(p P) bool {
+func (v *Variant[P, V]) Is(val V) Matcher[P] {
+	return v.matcher("Is", func() Condition {
+		return Condition{Op: OpIs, Variant: v.schema(), Values: []any{val}}
+	}, func(p P) bool {
 		return v.get(p) == val
-	}}
+	}, val)
 }
 
 // In returns a new Matcher that matches if the variant value is in the given list of values.
-func (v Variant[P, V]) In(vals ...V) Matcher[P] {
-	return Matcher[P]{func(p P) bool {
+func (v *Variant[P, V]) In(vals ...V) Matcher[P] {
+	return v.matcher("In", func() Condition {
+		return Condition{Op: OpIn, Variant: v.schema(), Values: toAny(vals)}
+	}, func(p P) bool {
 		return slices.Contains(vals, v.get(p))
-	}}
+	}, vals...)
 }
 
 // IsNot returns a new Matcher that matches if the variant value is not equal to the given value.
-func (v Variant[P, V]) IsNot(val V) Matcher[P] {
+func (v *Variant[P, V]) IsNot(val V) Matcher[P] {
 	return v.Is(val).Not()
 }
 
 // NotIn returns a new Matcher that matches if the variant value is not in the given list of values.
-func (v Variant[P, V]) NotIn(vals ...V) Matcher[P] {
+func (v *Variant[P, V]) NotIn(vals ...V) Matcher[P] {
 	return v.In(vals...).Not()
 }
 
 // Map returns a new Option that applies the given classes if the variant value is in the given map.
-func (v Variant[P, V]) Map(m map[V]string) Option[P] {
-	return Classes(func(p P) []string {
-		if classes, ok := m[v.get(p)]; ok {
-			return []string{classes}
+func (v *Variant[P, V]) Map(m map[V]string) Option[P] {
+	classesMap := make(map[V][]string, len(m))
+	for k, classes := range m {
+		classesMap[k] = []string{classes}
+	}
+	keys := sortedKeys(classesMap)
+
+	p := mapProducer(v.get, classesMap)
+	p.validate = func(props P) []UnknownValue {
+		if v.values != nil {
+			return v.unknownValues(props)
 		}
-		return nil
-	})
+		return v.unknownValuesIn(props, keys)
+	}
+	p.problems = func() []string { return v.problems("Map", keys...) }
+	p.use = v.use
+	return newOption(func() OptionSchema { return mapSchema(v.schema(), classesMap) }, p)
 }
 
 // When returns a new Option that applies the given classes if the given matcher matches.
@@ -145,26 +313,26 @@ func When[P any](m Matcher[P], classes ...string) Option[P] {
 //
 // This is a convience method that is equivalent to chaining matchers with Matcher.Or.
 func Any[P any](matchers ...Matcher[P]) Matcher[P] {
-	return Matcher[P]{func(p P) bool {
+	return combine(OpOr, matchers, func(p P) bool {
 		for _, m := range matchers {
 			if m.fn(p) {
 				return true
 			}
 		}
 		return false
-	}}
+	})
 }
 
 // All returns a new Matcher that matches if all of the given matchers match.
 //
 // This is a convience method that is equivalent to chaining matchers with Matcher.And.
 func All[P any](matchers ...Matcher[P]) Matcher[P] {
-	return Matcher[P]{func(p P) bool {
+	return combine(OpAnd, matchers, func(p P) bool {
 		for _, m := range matchers {
 			if !m.fn(p) {
 				return false
 			}
 		}
 		return true
-	}}
+	})
 }
@@ -10,9 +10,9 @@ func TestMatcher(t *testing.T) {
 			Value int
 		}
 
-		matcher1 := Matcher[Props]{func(p Props) bool { return p.Value == 1 }}
-		matcher2 := Matcher[Props]{func(p Props) bool { return p.Value == 2 }}
-		matcher3 := Matcher[Props]{func(p Props) bool { return p.Value == 3 }}
+		matcher1 := Matcher[Props]{fn: func(p Props) bool { return p.Value == 1 }}
+		matcher2 := Matcher[Props]{fn: func(p Props) bool { return p.Value == 2 }}
+		matcher3 := Matcher[Props]{fn: func(p Props) bool { return p.Value == 3 }}
 
 		combined := matcher1.Or(matcher2, matcher3)
 
@@ -59,8 +59,8 @@ func TestMatcher(t *testing.T) {
 			Flag  bool
 		}
 
-		matcher1 := Matcher[Props]{func(p Props) bool { return p.Value > 0 }}
-		matcher2 := Matcher[Props]{func(p Props) bool { return p.Flag }}
+		matcher1 := Matcher[Props]{fn: func(p Props) bool { return p.Value > 0 }}
+		matcher2 := Matcher[Props]{fn: func(p Props) bool { return p.Flag }}
 
 		combined := matcher1.And(matcher2)
 
@@ -106,7 +106,7 @@ func TestMatcher(t *testing.T) {
 			Value int
 		}
 
-		matcher := Matcher[Props]{func(p Props) bool { return p.Value > 0 }}
+		matcher := Matcher[Props]{fn: func(p Props) bool { return p.Value > 0 }}
 		notMatcher := matcher.Not()
 
 		tests := []struct {
@@ -146,7 +146,7 @@ func TestMatcher(t *testing.T) {
 			Value int
 		}
 
-		matcher := Matcher[Props]{func(p Props) bool { return p.Value > 0 }}
+		matcher := Matcher[Props]{fn: func(p Props) bool { return p.Value > 0 }}
 		option := matcher.Then("positive", "number")
 
 		button := New(option)
@@ -475,6 +475,134 @@ func TestVariant(t *testing.T) {
 			})
 		}
 	})
+	t.Run("live_configuration", func(t *testing.T) {
+		type Props struct {
+			Value int
+		}
+
+		variant := NewVariant[Props, int](func(p Props) int { return p.Value })
+		is := variant.Is(2).Then("is-two")
+		in := variant.In(1, 3).Then("in-one-three")
+		mapped := variant.Map(map[int]string{2: "two", 4: "four"})
+		variant.WithName("value").WithDefault(2).WithValues(1, 2, 3)
+
+		button := New(is, in, mapped)
+
+		tests := []struct {
+			name  string
+			props Props
+			want  string
+		}{
+			{
+				name:  "default",
+				props: Props{},
+				want:  "is-two two",
+			},
+			{
+				name:  "known-value",
+				props: Props{Value: 3},
+				want:  "in-one-three",
+			},
+			{
+				name:  "unknown-value",
+				props: Props{Value: 4},
+				want:  "is-two two",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				got := button.Classes(test.props)
+				if got != test.want {
+					t.Errorf("got %s, want %s", got, test.want)
+				}
+			})
+		}
+
+		t.Run("schema", func(t *testing.T) {
+			for _, option := range button.Schema().Options {
+				got := option.Variants[0]
+				if got.Name != "value" || !got.HasDefault || got.Default != 2 || len(got.Values) != 3 {
+					t.Errorf("got %+v, want the variant's configuration", got)
+				}
+			}
+		})
+
+		t.Run("ClassesE", func(t *testing.T) {
+			_, err := button.ClassesE(Props{Value: 4})
+			if err == nil {
+				t.Fatalf("got nil error, want unknown value 4")
+			}
+		})
+
+		t.Run("NewE", func(t *testing.T) {
+			_, err := NewE(mapped)
+			if err == nil {
+				t.Fatalf("got nil error, want map key 4 outside of the variant's values")
+			}
+		})
+	})
+
+	t.Run("frozen_configuration", func(t *testing.T) {
+		type Props struct {
+			Value int
+		}
+
+		size := NewVariant(func(p Props) int { return p.Value }).WithName("size").WithDefault(1)
+		other := NewVariant(func(p Props) int { return p.Value })
+		unused := NewVariant(func(p Props) int { return p.Value })
+		matcher := size.Is(1).And(other.In(2)).Not()
+		unused.Is(1).Then("unused")
+		button := New(Cache[Props](0), size.Map(map[int]string{1: "one", 2: "two"}),
+			matcher.Then("not-both"))
+
+		configs := []struct {
+			name string
+			fn   func()
+			want string
+		}{
+			{
+				name: "WithName",
+				fn:   func() { size.WithName("other") },
+				want: `cva: Variant.WithName called on variant "size" after a component using it was created`,
+			},
+			{
+				name: "WithDefault",
+				fn:   func() { size.WithDefault(2) },
+				want: `cva: Variant.WithDefault called on variant "size" after a component using it was created`,
+			},
+			{
+				name: "WithValues",
+				fn:   func() { size.WithValues(1, 2) },
+				want: `cva: Variant.WithValues called on variant "size" after a component using it was created`,
+			},
+			{
+				name: "combined_matcher",
+				fn:   func() { other.WithDefault(2) },
+				want: `cva: Variant.WithDefault called on unnamed variant after a component using it was created`,
+			},
+		}
+
+		for _, config := range configs {
+			t.Run(config.name, func(t *testing.T) {
+				defer func() {
+					if got := recover(); got != config.want {
+						t.Errorf("got %v, want panic %s", got, config.want)
+					}
+				}()
+				config.fn()
+			})
+		}
+
+		if got, want := button.Classes(Props{}), "one not-both"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		if got := button.Schema().Options[0].Variants[0]; got.Name != "size" || got.Default != 1 {
+			t.Errorf("got %+v, want variant size defaulting to 1", got)
+		}
+		// Deriving options without creating a component does not freeze the variant.
+		unused.WithDefault(2)
+	})
 }
 
 func TestConvenienceFunctions(t *testing.T) {
@@ -483,7 +611,7 @@ func TestConvenienceFunctions(t *testing.T) {
 			Value int
 		}
 
-		matcher := Matcher[Props]{func(p Props) bool { return p.Value > 0 }}
+		matcher := Matcher[Props]{fn: func(p Props) bool { return p.Value > 0 }}
 		option := When(matcher, "positive", "number")
 
 		button := New(option)
@@ -520,9 +648,9 @@ func TestConvenienceFunctions(t *testing.T) {
 			Value int
 		}
 
-		matcher1 := Matcher[Props]{func(p Props) bool { return p.Value == 1 }}
-		matcher2 := Matcher[Props]{func(p Props) bool { return p.Value == 2 }}
-		matcher3 := Matcher[Props]{func(p Props) bool { return p.Value == 3 }}
+		matcher1 := Matcher[Props]{fn: func(p Props) bool { return p.Value == 1 }}
+		matcher2 := Matcher[Props]{fn: func(p Props) bool { return p.Value == 2 }}
+		matcher3 := Matcher[Props]{fn: func(p Props) bool { return p.Value == 3 }}
 
 		combined := Any(matcher1, matcher2, matcher3)
 
@@ -569,8 +697,8 @@ func TestConvenienceFunctions(t *testing.T) {
 			Flag  bool
 		}
 
-		matcher1 := Matcher[Props]{func(p Props) bool { return p.Value > 0 }}
-		matcher2 := Matcher[Props]{func(p Props) bool { return p.Flag }}
+		matcher1 := Matcher[Props]{fn: func(p Props) bool { return p.Value > 0 }}
+		matcher2 := Matcher[Props]{fn: func(p Props) bool { return p.Flag }}
 
 		combined := All(matcher1, matcher2)
 